@@ -0,0 +1,137 @@
+// Package accountotel instruments a Client of package account with OpenTelemetry spans.
+//
+//	client := account.NewClient(account.WithTracer(accountotel.NewTracer()))
+//
+// Every operation gets a span, with a child span for every http attempt, and the
+// W3C traceparent header of the attempt span is propagated to the form3 API.
+package accountotel
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/edihoxhalli/Form3-exercise/account"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// InstrumentationName is the name of the OpenTelemetry tracer creating the spans.
+const InstrumentationName = "github.com/edihoxhalli/Form3-exercise/account/accountotel"
+
+// Attribute keys set on the spans, besides the OpenTelemetry semantic conventions
+// for http clients.
+const (
+	OperationKey      = attribute.Key("account.operation")
+	AccountIDKey      = attribute.Key("account.id")
+	OrganisationIDKey = attribute.Key("account.organisation_id")
+	StatusCodeKey     = attribute.Key("http.response.status_code")
+	ErrorTypeKey      = attribute.Key("error.type")
+	MethodKey         = attribute.Key("http.request.method")
+	ResendCountKey    = attribute.Key("http.request.resend_count")
+)
+
+// Option configures a Tracer created through NewTracer.
+type Option func(*Tracer)
+
+// WithTracerProvider sets the TracerProvider creating the spans.
+// Defaults to the global TracerProvider.
+func WithTracerProvider(provider trace.TracerProvider) Option {
+	return func(t *Tracer) {
+		t.provider = provider
+	}
+}
+
+// WithPropagator sets the propagator injecting the trace context into the requests.
+// Defaults to the W3C trace context propagator.
+func WithPropagator(propagator propagation.TextMapPropagator) Option {
+	return func(t *Tracer) {
+		t.propagator = propagator
+	}
+}
+
+// Tracer is an account.Tracer creating OpenTelemetry spans.
+type Tracer struct {
+	provider   trace.TracerProvider
+	propagator propagation.TextMapPropagator
+	tracer     trace.Tracer
+}
+
+// NewTracer returns a Tracer configured with the given options.
+func NewTracer(opts ...Option) *Tracer {
+	t := &Tracer{
+		provider:   otel.GetTracerProvider(),
+		propagator: propagation.TraceContext{},
+	}
+	for _, opt := range opts {
+		opt(t)
+	}
+	t.tracer = t.provider.Tracer(InstrumentationName)
+	return t
+}
+
+// StartOperation starts the span of op, named after it, e.g. "account.create".
+func (t *Tracer) StartOperation(ctx context.Context, op account.Operation) (context.Context, account.EndFunc) {
+	attrs := []attribute.KeyValue{OperationKey.String(op.Name)}
+	if op.AccountID != "" {
+		attrs = append(attrs, AccountIDKey.String(op.AccountID))
+	}
+	if op.OrganisationID != "" {
+		attrs = append(attrs, OrganisationIDKey.String(op.OrganisationID))
+	}
+	ctx, span := t.tracer.Start(ctx, "account."+op.Name,
+		trace.WithSpanKind(trace.SpanKindInternal), trace.WithAttributes(attrs...))
+	return ctx, endSpan(span)
+}
+
+// StartAttempt starts the client span of an http attempt, named after its method,
+// and injects its trace context into the request headers.
+func (t *Tracer) StartAttempt(req *http.Request, attempt int) account.EndFunc {
+	attrs := []attribute.KeyValue{MethodKey.String(req.Method)}
+	if attempt > 1 {
+		attrs = append(attrs, ResendCountKey.Int(attempt-1))
+	}
+	ctx, span := t.tracer.Start(req.Context(), req.Method,
+		trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(attrs...))
+	t.propagator.Inject(ctx, propagation.HeaderCarrier(req.Header))
+	return endSpan(span)
+}
+
+func endSpan(span trace.Span) account.EndFunc {
+	return func(statusCode int, err error) {
+		if statusCode != 0 {
+			span.SetAttributes(StatusCodeKey.Int(statusCode))
+		}
+		if errorType := ErrorType(statusCode, err); errorType != "" {
+			span.SetAttributes(ErrorTypeKey.String(errorType))
+			description := errorType
+			if err != nil {
+				span.RecordError(err)
+				description = err.Error()
+			}
+			span.SetStatus(codes.Error, description)
+		}
+		span.End()
+	}
+}
+
+// ErrorType returns the error.type attribute of a failed operation or attempt:
+// the status code of error responses, the type of other errors, or "" on success.
+func ErrorType(statusCode int, err error) string {
+	var apiErr *account.ApiError
+	switch {
+	case errors.As(err, &apiErr):
+		return strconv.Itoa(apiErr.StatusCode)
+	case err != nil:
+		return fmt.Sprintf("%T", err)
+	case statusCode >= http.StatusBadRequest:
+		return strconv.Itoa(statusCode)
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,146 @@
+package accountotel_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/edihoxhalli/Form3-exercise/account"
+	"github.com/edihoxhalli/Form3-exercise/account/accountotel"
+	"github.com/edihoxhalli/Form3-exercise/account/accounttest"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func attributeOf(span sdktrace.ReadOnlySpan, key attribute.Key) (attribute.Value, bool) {
+	for _, kv := range span.Attributes() {
+		if kv.Key == key {
+			return kv.Value, true
+		}
+	}
+	return attribute.Value{}, false
+}
+
+func TestTracer(t *testing.T) {
+	server := accounttest.NewServer()
+	defer server.Close()
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	var traceparents []string
+	policy := account.DefaultRetryPolicy()
+	policy.BaseDelay, policy.MaxDelay = time.Millisecond, time.Millisecond
+	client := server.Client(
+		account.WithRetryPolicy(policy),
+		account.WithTracer(accountotel.NewTracer(accountotel.WithTracerProvider(provider))),
+		account.WithMiddleware(func(next http.RoundTripper) http.RoundTripper {
+			return account.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				traceparents = append(traceparents, req.Header.Get("traceparent"))
+				return next.RoundTrip(req)
+			})
+		}))
+
+	id := uuid.New()
+	server.ScriptFaults(accounttest.EndpointFetch, accounttest.Fault{StatusCode: http.StatusServiceUnavailable})
+	_, err := client.FetchContext(context.Background(), id)
+	if !errors.Is(err, account.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got (%v)", err)
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 3 {
+		t.Fatalf("expected 3 spans, got %d", len(spans))
+	}
+	operation := spans[2]
+	if operation.Name() != "account.fetch" || operation.SpanKind() != trace.SpanKindInternal {
+		t.Errorf("unexpected operation span (%s, %s)", operation.Name(), operation.SpanKind())
+	}
+	if v, _ := attributeOf(operation, accountotel.AccountIDKey); v.AsString() != id.String() {
+		t.Errorf("expected account id (%s), got (%s)", id, v.AsString())
+	}
+	if v, _ := attributeOf(operation, accountotel.ErrorTypeKey); v.AsString() != "404" {
+		t.Errorf("expected error type (404), got (%s)", v.AsString())
+	}
+	if operation.Status().Code != codes.Error {
+		t.Errorf("expected error status, got (%v)", operation.Status())
+	}
+
+	for i, attempt := range spans[:2] {
+		if attempt.Name() != http.MethodGet || attempt.SpanKind() != trace.SpanKindClient {
+			t.Errorf("unexpected attempt span (%s, %s)", attempt.Name(), attempt.SpanKind())
+		}
+		if attempt.Parent().SpanID() != operation.SpanContext().SpanID() {
+			t.Errorf("expected attempt %d to be a child of the operation span", i+1)
+		}
+		want := "00-" + attempt.SpanContext().TraceID().String() + "-" + attempt.SpanContext().SpanID().String() + "-01"
+		if traceparents[i] != want {
+			t.Errorf("expected traceparent (%s) on attempt %d, got (%s)", want, i+1, traceparents[i])
+		}
+	}
+	if v, _ := attributeOf(spans[0], accountotel.StatusCodeKey); v.AsInt64() != http.StatusServiceUnavailable {
+		t.Errorf("expected status (503) on first attempt, got (%d)", v.AsInt64())
+	}
+	if v, ok := attributeOf(spans[1], accountotel.ResendCountKey); !ok || v.AsInt64() != 1 {
+		t.Errorf("expected resend count (1) on second attempt, got (%d)", v.AsInt64())
+	}
+}
+
+func TestTracerCreate(t *testing.T) {
+	server := accounttest.NewServer()
+	defer server.Close()
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	client := server.Client(account.WithTracer(accountotel.NewTracer(accountotel.WithTracerProvider(provider))))
+
+	country := "GB"
+	acc := account.Account{Data: &account.AccountData{
+		ID:             uuid.NewString(),
+		OrganisationID: uuid.NewString(),
+		Type:           "accounts",
+		Attributes:     &account.AccountAttributes{Country: &country},
+	}}
+	if _, err := client.Create(acc); err != nil {
+		t.Fatalf("unexpected error (%v)", err)
+	}
+	spans := recorder.Ended()
+	if len(spans) != 2 {
+		t.Fatalf("expected 2 spans, got %d", len(spans))
+	}
+	operation := spans[1]
+	if v, _ := attributeOf(operation, accountotel.OrganisationIDKey); v.AsString() != acc.Data.OrganisationID {
+		t.Errorf("expected organisation id (%s), got (%s)", acc.Data.OrganisationID, v.AsString())
+	}
+	if v, _ := attributeOf(operation, accountotel.StatusCodeKey); v.AsInt64() != http.StatusCreated {
+		t.Errorf("expected status (201), got (%d)", v.AsInt64())
+	}
+	if _, ok := attributeOf(operation, accountotel.ErrorTypeKey); ok || operation.Status().Code == codes.Error {
+		t.Error("expected no error on successful create")
+	}
+}
+
+func TestErrorType(t *testing.T) {
+	subtests := []struct {
+		name       string
+		statusCode int
+		err        error
+		expected   string
+	}{
+		{"success", http.StatusOK, nil, ""},
+		{"error response", 0, &account.VersionConflictError{ApiError: &account.ApiError{StatusCode: http.StatusConflict}}, "409"},
+		{"retryable response", http.StatusServiceUnavailable, nil, "503"},
+		{"canceled", 0, &account.RequestCanceledError{Err: context.Canceled}, "*account.RequestCanceledError"},
+	}
+	for _, subtest := range subtests {
+		t.Run(subtest.name, func(t *testing.T) {
+			if got := accountotel.ErrorType(subtest.statusCode, subtest.err); got != subtest.expected {
+				t.Errorf("expected (%s), got (%s)", subtest.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,122 @@
+// Package accountprom records the operations of a Client of package account as Prometheus metrics.
+//
+//	metrics, err := accountprom.NewMetrics(prometheus.DefaultRegisterer)
+//	...
+//	client := account.NewClient(account.WithMetrics(metrics))
+package accountprom
+
+import (
+	"time"
+
+	"github.com/edihoxhalli/Form3-exercise/account"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// DefaultNamespace is the namespace of the metrics, unless set through WithNamespace.
+const DefaultNamespace = "form3_account"
+
+// Option configures Metrics created through NewMetrics.
+type Option func(*config)
+
+type config struct {
+	namespace   string
+	buckets     []float64
+	constLabels prometheus.Labels
+}
+
+// WithNamespace sets the namespace prefixing the metric names.
+func WithNamespace(namespace string) Option {
+	return func(c *config) {
+		c.namespace = namespace
+	}
+}
+
+// WithBuckets sets the buckets of the latency histogram, in seconds.
+// Defaults to prometheus.DefBuckets.
+func WithBuckets(buckets []float64) Option {
+	return func(c *config) {
+		c.buckets = buckets
+	}
+}
+
+// WithConstLabels sets labels added to every metric, e.g. the form3 environment.
+func WithConstLabels(labels prometheus.Labels) Option {
+	return func(c *config) {
+		c.constLabels = labels
+	}
+}
+
+// Metrics is an account.Metrics recording:
+//   - <namespace>_requests_total, a counter of operations by operation and status_class;
+//   - <namespace>_request_duration_seconds, a histogram of operation latencies by operation and status_class;
+//   - <namespace>_retries_total, a counter of retries by operation;
+//   - <namespace>_requests_in_flight, a gauge of operations in flight by operation.
+type Metrics struct {
+	requests *prometheus.CounterVec
+	duration *prometheus.HistogramVec
+	retries  *prometheus.CounterVec
+	inFlight *prometheus.GaugeVec
+}
+
+// NewMetrics returns Metrics registered with registerer, configured with the given options.
+// It returns an error if the metrics cannot be registered, e.g. because they already are,
+// in which case none of them is left registered.
+func NewMetrics(registerer prometheus.Registerer, opts ...Option) (*Metrics, error) {
+	c := config{namespace: DefaultNamespace, buckets: prometheus.DefBuckets}
+	for _, opt := range opts {
+		opt(&c)
+	}
+	m := &Metrics{
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace:   c.namespace,
+			Name:        "requests_total",
+			Help:        "Number of account operations, by operation and response status class.",
+			ConstLabels: c.constLabels,
+		}, []string{"operation", "status_class"}),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace:   c.namespace,
+			Name:        "request_duration_seconds",
+			Help:        "Latency of account operations including retries, by operation and response status class.",
+			Buckets:     c.buckets,
+			ConstLabels: c.constLabels,
+		}, []string{"operation", "status_class"}),
+		retries: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace:   c.namespace,
+			Name:        "retries_total",
+			Help:        "Number of retried account requests, by operation.",
+			ConstLabels: c.constLabels,
+		}, []string{"operation"}),
+		inFlight: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace:   c.namespace,
+			Name:        "requests_in_flight",
+			Help:        "Number of account operations in flight, by operation.",
+			ConstLabels: c.constLabels,
+		}, []string{"operation"}),
+	}
+	collectors := []prometheus.Collector{m.requests, m.duration, m.retries, m.inFlight}
+	for i, collector := range collectors {
+		if err := registerer.Register(collector); err != nil {
+			for _, registered := range collectors[:i] {
+				registerer.Unregister(registered)
+			}
+			return nil, err
+		}
+	}
+	return m, nil
+}
+
+var _ account.Metrics = (*Metrics)(nil)
+
+func (m *Metrics) OperationStarted(operation string) {
+	m.inFlight.WithLabelValues(operation).Inc()
+}
+
+func (m *Metrics) OperationDone(operation, statusClass string, latency time.Duration) {
+	m.inFlight.WithLabelValues(operation).Dec()
+	m.requests.WithLabelValues(operation, statusClass).Inc()
+	m.duration.WithLabelValues(operation, statusClass).Observe(latency.Seconds())
+}
+
+func (m *Metrics) Retried(operation string) {
+	m.retries.WithLabelValues(operation).Inc()
+}
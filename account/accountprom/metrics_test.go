@@ -0,0 +1,87 @@
+package accountprom_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/edihoxhalli/Form3-exercise/account"
+	"github.com/edihoxhalli/Form3-exercise/account/accountprom"
+	"github.com/edihoxhalli/Form3-exercise/account/accounttest"
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestMetrics(t *testing.T) {
+	server := accounttest.NewServer()
+	defer server.Close()
+	registry := prometheus.NewPedanticRegistry()
+	metrics, err := accountprom.NewMetrics(registry, accountprom.WithNamespace("test"))
+	if err != nil {
+		t.Fatalf("unexpected error (%v)", err)
+	}
+	policy := account.DefaultRetryPolicy()
+	policy.BaseDelay, policy.MaxDelay = time.Millisecond, time.Millisecond
+	client := server.Client(account.WithRetryPolicy(policy), account.WithMetrics(metrics))
+
+	country := "GB"
+	acc := account.Account{Data: &account.AccountData{
+		ID:             uuid.NewString(),
+		OrganisationID: uuid.NewString(),
+		Type:           "accounts",
+		Attributes:     &account.AccountAttributes{Country: &country},
+	}}
+	if _, err := client.Create(acc); err != nil {
+		t.Fatalf("unexpected error (%v)", err)
+	}
+	server.ScriptFaults(accounttest.EndpointFetch, accounttest.Repeat(2, accounttest.Fault{StatusCode: http.StatusBadGateway})...)
+	if _, err := client.FetchContext(context.Background(), uuid.New()); !errors.Is(err, account.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got (%v)", err)
+	}
+
+	expected := `
+# HELP test_requests_in_flight Number of account operations in flight, by operation.
+# TYPE test_requests_in_flight gauge
+test_requests_in_flight{operation="create"} 0
+test_requests_in_flight{operation="fetch"} 0
+# HELP test_requests_total Number of account operations, by operation and response status class.
+# TYPE test_requests_total counter
+test_requests_total{operation="create",status_class="2xx"} 1
+test_requests_total{operation="fetch",status_class="4xx"} 1
+# HELP test_retries_total Number of retried account requests, by operation.
+# TYPE test_retries_total counter
+test_retries_total{operation="fetch"} 2
+`
+	if err := testutil.GatherAndCompare(registry, strings.NewReader(expected),
+		"test_requests_in_flight", "test_requests_total", "test_retries_total"); err != nil {
+		t.Error(err)
+	}
+	if n := testutil.CollectAndCount(registry, "test_request_duration_seconds"); n != 2 {
+		t.Errorf("expected 2 latency histograms, got %d", n)
+	}
+	if _, err := accountprom.NewMetrics(registry, accountprom.WithNamespace("test")); err == nil {
+		t.Error("expected an error registering the metrics twice")
+	}
+}
+
+func TestMetricsRegistrationFailure(t *testing.T) {
+	registry := prometheus.NewPedanticRegistry()
+	conflicting := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "test",
+		Name:      "retries_total",
+		Help:      "Number of retried account requests, by operation.",
+	}, []string{"operation"})
+	registry.MustRegister(conflicting)
+	if _, err := accountprom.NewMetrics(registry, accountprom.WithNamespace("test")); err == nil {
+		t.Fatal("expected an error registering an already registered metric")
+	}
+
+	registry.Unregister(conflicting)
+	if _, err := accountprom.NewMetrics(registry, accountprom.WithNamespace("test")); err != nil {
+		t.Errorf("expected the metrics registered before the failure to be unregistered, got (%v)", err)
+	}
+}
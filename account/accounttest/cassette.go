@@ -0,0 +1,271 @@
+package accounttest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// Redacted replaces the values of scrubbed headers in a Cassette.
+const Redacted = "[REDACTED]"
+
+// DefaultScrubbedHeaders lists the headers whose values are never written to a Cassette.
+var DefaultScrubbedHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie"}
+
+// DefaultDroppedHeaders lists the headers left out of a Cassette, since their values
+// change on every recording (e.g. the port of the recorded server, or the current time).
+var DefaultDroppedHeaders = []string{"Host", "Date"}
+
+// RecordedRequest is the part of a request stored in a Cassette.
+// Method, Path, Query and Body are used to match replayed requests.
+type RecordedRequest struct {
+	Method string      `json:"method"`
+	Path   string      `json:"path"`
+	Query  string      `json:"query,omitempty"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body,omitempty"`
+}
+
+// RecordedResponse is the part of a response stored in a Cassette.
+type RecordedResponse struct {
+	StatusCode int         `json:"status_code"`
+	Status     string      `json:"status"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+// Interaction is a recorded request/response pair.
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// Cassette holds the interactions captured by a Recorder, to be served back by a Replayer.
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// LoadCassette reads a Cassette from the golden file at path.
+func LoadCassette(path string) (*Cassette, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var cassette Cassette
+	if err := json.Unmarshal(content, &cassette); err != nil {
+		return nil, err
+	}
+	return &cassette, nil
+}
+
+// Save writes the Cassette as an indented golden file at path, creating its directory if needed.
+func (c *Cassette) Save(path string) error {
+	content, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, append(content, '\n'), 0644)
+}
+
+// Recorder is an http.RoundTripper capturing every request/response pair going through it,
+// e.g. against a running accountapi, with the values of scrubbed headers redacted
+// and the dropped headers left out.
+// It is safe for concurrent use.
+type Recorder struct {
+	// Transport executes the requests. Defaults to http.DefaultTransport.
+	Transport http.RoundTripper
+	// ScrubHeaders lists the headers to redact. Defaults to DefaultScrubbedHeaders.
+	ScrubHeaders []string
+	// DropHeaders lists the headers to leave out. Defaults to DefaultDroppedHeaders.
+	DropHeaders []string
+
+	mu       sync.Mutex
+	cassette Cassette
+}
+
+// RoundTrip executes req through the underlying Transport and records the interaction.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	reqBody, req, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+	transport := r.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	response, err := transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	resBody, err := readAndRestore(&response.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	scrub := r.ScrubHeaders
+	if scrub == nil {
+		scrub = DefaultScrubbedHeaders
+	}
+	drop := r.DropHeaders
+	if drop == nil {
+		drop = DefaultDroppedHeaders
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cassette.Interactions = append(r.cassette.Interactions, Interaction{
+		Request: RecordedRequest{
+			Method: req.Method,
+			Path:   req.URL.Path,
+			Query:  req.URL.RawQuery,
+			Header: scrubbed(req.Header, scrub, drop),
+			Body:   string(reqBody),
+		},
+		Response: RecordedResponse{
+			StatusCode: response.StatusCode,
+			Status:     response.Status,
+			Header:     scrubbed(response.Header, scrub, drop),
+			Body:       string(resBody),
+		},
+	})
+	return response, nil
+}
+
+// Cassette returns a copy of the interactions recorded so far.
+func (r *Recorder) Cassette() *Cassette {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return &Cassette{Interactions: append([]Interaction(nil), r.cassette.Interactions...)}
+}
+
+// Save writes the interactions recorded so far as a golden file at path.
+func (r *Recorder) Save(path string) error {
+	return r.Cassette().Save(path)
+}
+
+// Replayer is an http.RoundTripper serving back the interactions of a Cassette,
+// without any network access. A request is answered by the first interaction not served yet
+// whose method, path, query and body match, so that repeated identical requests
+// get their responses in recording order.
+// It is safe for concurrent use.
+type Replayer struct {
+	mu       sync.Mutex
+	cassette *Cassette
+	served   []bool
+}
+
+// NewReplayer returns a Replayer serving the interactions of cassette.
+func NewReplayer(cassette *Cassette) *Replayer {
+	return &Replayer{cassette: cassette, served: make([]bool, len(cassette.Interactions))}
+}
+
+// RoundTrip returns the recorded response matching req, or an error if there is none.
+func (r *Replayer) RoundTrip(req *http.Request) (*http.Response, error) {
+	reqBody, _, err := readRequestBody(req)
+	if req.Body != nil {
+		req.Body.Close()
+	}
+	if err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, interaction := range r.cassette.Interactions {
+		recorded := interaction.Request
+		if r.served[i] || recorded.Method != req.Method || recorded.Path != req.URL.Path ||
+			recorded.Query != req.URL.RawQuery || recorded.Body != string(reqBody) {
+			continue
+		}
+		r.served[i] = true
+		return &http.Response{
+			StatusCode:    interaction.Response.StatusCode,
+			Status:        interaction.Response.Status,
+			Header:        interaction.Response.Header.Clone(),
+			Body:          ioutil.NopCloser(bytes.NewBufferString(interaction.Response.Body)),
+			ContentLength: int64(len(interaction.Response.Body)),
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Request:       req,
+		}, nil
+	}
+	return nil, fmt.Errorf("accounttest: no recorded interaction for %s %s?%s", req.Method, req.URL.Path, req.URL.RawQuery)
+}
+
+// Unused returns the interactions which have not been served yet,
+// so that tests can check that every recorded request was replayed.
+func (r *Replayer) Unused() []Interaction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var unused []Interaction
+	for i, interaction := range r.cassette.Interactions {
+		if !r.served[i] {
+			unused = append(unused, interaction)
+		}
+	}
+	return unused
+}
+
+// readRequestBody returns the body of req, along with a request sending the same body
+// to the next http.RoundTripper. As req must not be modified, its body is read through
+// req.GetBody when possible, otherwise from a clone of req given a new body.
+func readRequestBody(req *http.Request) ([]byte, *http.Request, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, req, nil
+	}
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, nil, err
+		}
+		defer body.Close()
+		content, err := ioutil.ReadAll(body)
+		return content, req, err
+	}
+	content, err := ioutil.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return nil, nil, err
+	}
+	clone := req.Clone(req.Context())
+	clone.Body = ioutil.NopCloser(bytes.NewReader(content))
+	return content, clone, nil
+}
+
+// readAndRestore reads the whole body and replaces it with an equivalent unread one.
+func readAndRestore(body *io.ReadCloser) ([]byte, error) {
+	if *body == nil || *body == http.NoBody {
+		return nil, nil
+	}
+	content, err := ioutil.ReadAll(*body)
+	(*body).Close()
+	if err != nil {
+		return nil, err
+	}
+	*body = ioutil.NopCloser(bytes.NewReader(content))
+	return content, nil
+}
+
+func scrubbed(header http.Header, scrub, drop []string) http.Header {
+	clone := header.Clone()
+	for _, name := range scrub {
+		if clone.Get(name) != "" {
+			clone.Set(name, Redacted)
+		}
+	}
+	for _, name := range drop {
+		clone.Del(name)
+	}
+	if len(clone) == 0 {
+		return nil
+	}
+	return clone
+}
@@ -0,0 +1,100 @@
+package accounttest
+
+import (
+	"io"
+	"net/http"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/edihoxhalli/Form3-exercise/account"
+	"github.com/google/uuid"
+)
+
+func TestRecordAndReplay(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	recorder := &Recorder{}
+	auth := account.WithAuthenticator(account.AuthenticatorFunc(func(req *http.Request) error {
+		req.Header.Set("Authorization", "Bearer secret")
+		return nil
+	}))
+	recording := server.Client(auth, account.WithHttpClient(&http.Client{Transport: recorder}))
+	acc := newAccount("GB", "400300")
+	id := uuid.MustParse(acc.Data.ID)
+	if _, err := recording.Create(acc); err != nil {
+		t.Fatalf("unexpected error (%v)", err)
+	}
+	expFetched, err := recording.Fetch(id)
+	if err != nil {
+		t.Fatalf("unexpected error (%v)", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	if err := recorder.Save(path); err != nil {
+		t.Fatalf("unexpected error (%v)", err)
+	}
+	cassette, err := LoadCassette(path)
+	if err != nil {
+		t.Fatalf("unexpected error (%v)", err)
+	}
+	if len(cassette.Interactions) != 2 {
+		t.Fatalf("expected 2 interactions, got (%d)", len(cassette.Interactions))
+	}
+	for _, interaction := range cassette.Interactions {
+		if got := interaction.Request.Header.Get("Authorization"); got != Redacted {
+			t.Errorf("expected scrubbed Authorization header, got (%s)", got)
+		}
+		for _, header := range []http.Header{interaction.Request.Header, interaction.Response.Header} {
+			if header.Get("Host") != "" || header.Get("Date") != "" {
+				t.Errorf("expected Host and Date headers to be dropped, got (%v)", header)
+			}
+		}
+	}
+
+	server.Close()
+	replayer := NewReplayer(cassette)
+	replaying := account.NewClient(account.WithHost("http://unreachable/"), account.WithHttpClient(&http.Client{Transport: replayer}))
+	if _, err := replaying.Create(acc); err != nil {
+		t.Errorf("expected replayed create, got error (%v)", err)
+	}
+	fetched, err := replaying.Fetch(id)
+	if err != nil || !reflect.DeepEqual(fetched, expFetched) {
+		t.Errorf("expected replayed fetch (%+v), got (%+v) and error (%v)", expFetched, fetched, err)
+	}
+	if unused := replayer.Unused(); len(unused) != 0 {
+		t.Errorf("expected every interaction to be replayed, got (%d) unused", len(unused))
+	}
+
+	_, err = replaying.Fetch(id)
+	if err == nil || !strings.Contains(err.Error(), "no recorded interaction") {
+		t.Errorf("expected no recorded interaction error once the cassette is exhausted, got (%v)", err)
+	}
+}
+
+func TestRecorderDoesNotModifyRequest(t *testing.T) {
+	var sent string
+	recorder := &Recorder{Transport: account.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		content, _ := io.ReadAll(req.Body)
+		sent = string(content)
+		return &http.Response{StatusCode: http.StatusNoContent, Status: "204 No Content", Body: http.NoBody}, nil
+	})}
+	for _, withGetBody := range []bool{true, false} {
+		req, _ := http.NewRequest(http.MethodPost, "http://localhost/v1/organisation/accounts", strings.NewReader("account"))
+		if !withGetBody {
+			req.GetBody = nil
+		}
+		body := req.Body
+		if _, err := recorder.RoundTrip(req); err != nil {
+			t.Fatalf("unexpected error (%v)", err)
+		}
+		if req.Body != body || sent != "account" {
+			t.Errorf("expected the request body to be sent as is and left in place, got (%s)", sent)
+		}
+	}
+	cassette := recorder.Cassette()
+	if len(cassette.Interactions) != 2 || cassette.Interactions[1].Request.Body != "account" {
+		t.Errorf("expected the request bodies to be recorded, got (%+v)", cassette.Interactions)
+	}
+}
@@ -0,0 +1,194 @@
+package accounttest
+
+import (
+	"math/rand"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Endpoint identifies an endpoint of the fake, to which faults can be scripted.
+type Endpoint string
+
+const (
+	EndpointCreate Endpoint = "create"
+	EndpointFetch  Endpoint = "fetch"
+	EndpointList   Endpoint = "list"
+	EndpointUpdate Endpoint = "update"
+	EndpointDelete Endpoint = "delete"
+	// EndpointAny applies a fault to every endpoint. Faults scripted for a specific
+	// endpoint take precedence over the ones scripted for EndpointAny.
+	EndpointAny Endpoint = "any"
+)
+
+// Fault describes how the fake misbehaves on a call. The zero Fault lets the call succeed normally.
+// Latency is applied first; then ResetConnection, StatusCode and the body/status alterations are
+// mutually exclusive, in this order of precedence.
+type Fault struct {
+	// Latency delays the response. RandomLatency adds a random delay between 0 and its value.
+	Latency       time.Duration
+	RandomLatency time.Duration
+	// ResetConnection closes the connection with a TCP reset, without any response.
+	ResetConnection bool
+	// StatusCode makes the fake reply with this status and a JSON:API error body,
+	// without handling the request.
+	StatusCode int
+	// RetryAfter is sent as Retry-After header, in seconds, along with StatusCode.
+	RetryAfter time.Duration
+	// WrongStatusCode handles the request, but replies with this status code instead
+	// of the expected one, e.g. 200 on create.
+	WrongStatusCode int
+	// TruncateBody handles the request, but sends half of the response body only,
+	// closing the connection early.
+	TruncateBody bool
+	// MalformedBody handles the request, but replies with an invalid JSON body.
+	MalformedBody bool
+}
+
+// Repeat returns n copies of fault, e.g. to script a burst of 5xx.
+func Repeat(n int, fault Fault) []Fault {
+	faults := make([]Fault, n)
+	for i := range faults {
+		faults[i] = fault
+	}
+	return faults
+}
+
+// ScriptFaults queues faults for the next calls to endpoint: the first queued fault is
+// applied to the next call, the second one to the call after, and so on.
+// Once the queue is empty, the fault set through SetFault, if any, applies.
+func (s *Server) ScriptFaults(endpoint Endpoint, faults ...Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.scripted[endpoint] = append(s.scripted[endpoint], faults...)
+}
+
+// SetFault sets the fault applied to every call to endpoint without a scripted fault.
+func (s *Server) SetFault(endpoint Endpoint, fault Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.persistent[endpoint] = fault
+}
+
+// ClearFaults removes every scripted and persistent fault.
+func (s *Server) ClearFaults() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.scripted = make(map[Endpoint][]Fault)
+	s.persistent = make(map[Endpoint]Fault)
+}
+
+// Calls returns the number of calls received by endpoint, including the faulty ones.
+// Calls(EndpointAny) returns the total number of calls.
+func (s *Server) Calls(endpoint Endpoint) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.calls[endpoint]
+}
+
+// nextFault counts the call to endpoint and returns the fault to apply to it.
+func (s *Server) nextFault(endpoint Endpoint) Fault {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.calls[endpoint]++
+	s.calls[EndpointAny]++
+	for _, e := range []Endpoint{endpoint, EndpointAny} {
+		if queue := s.scripted[e]; len(queue) > 0 {
+			s.scripted[e] = queue[1:]
+			return queue[0]
+		}
+	}
+	for _, e := range []Endpoint{endpoint, EndpointAny} {
+		if fault, ok := s.persistent[e]; ok {
+			return fault
+		}
+	}
+	return Fault{}
+}
+
+func endpointOf(r *http.Request) Endpoint {
+	if r.URL.Path == accountsPath {
+		if r.Method == http.MethodPost {
+			return EndpointCreate
+		}
+		return EndpointList
+	}
+	switch r.Method {
+	case http.MethodPatch:
+		return EndpointUpdate
+	case http.MethodDelete:
+		return EndpointDelete
+	default:
+		return EndpointFetch
+	}
+}
+
+// serveWithFaults applies the next fault of the endpoint of r around the regular handler.
+func (s *Server) serveWithFaults(w http.ResponseWriter, r *http.Request) {
+	if !strings.HasPrefix(r.URL.Path, accountsPath) {
+		s.serveHTTP(w, r)
+		return
+	}
+	fault := s.nextFault(endpointOf(r))
+
+	delay := fault.Latency
+	if fault.RandomLatency > 0 {
+		delay += time.Duration(rand.Int63n(int64(fault.RandomLatency)))
+	}
+	if delay > 0 {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-time.After(delay):
+		}
+	}
+
+	switch {
+	case fault.ResetConnection:
+		resetConnection(w)
+	case fault.StatusCode != 0:
+		if fault.RetryAfter > 0 {
+			w.Header().Set("Retry-After", strconv.Itoa(int(fault.RetryAfter/time.Second)))
+		}
+		writeError(w, fault.StatusCode, "injected fault: "+http.StatusText(fault.StatusCode))
+	case fault.WrongStatusCode != 0 || fault.TruncateBody || fault.MalformedBody:
+		recorder := httptest.NewRecorder()
+		s.serveHTTP(recorder, r)
+		for name, values := range recorder.Header() {
+			w.Header()[name] = values
+		}
+		statusCode, body := recorder.Code, recorder.Body.Bytes()
+		if fault.WrongStatusCode != 0 {
+			statusCode = fault.WrongStatusCode
+		}
+		if fault.MalformedBody {
+			body = []byte(`{"data":{"id":`)
+		}
+		if fault.TruncateBody {
+			w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+			body = body[:len(body)/2]
+		}
+		w.WriteHeader(statusCode)
+		w.Write(body)
+	default:
+		s.serveHTTP(w, r)
+	}
+}
+
+func resetConnection(w http.ResponseWriter) {
+	hijacker, ok := w.(http.Hijacker)
+	if !ok {
+		panic(http.ErrAbortHandler)
+	}
+	conn, _, err := hijacker.Hijack()
+	if err != nil {
+		panic(http.ErrAbortHandler)
+	}
+	if tcpConn, ok := conn.(*net.TCPConn); ok {
+		tcpConn.SetLinger(0)
+	}
+	conn.Close()
+}
@@ -0,0 +1,170 @@
+package accounttest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/edihoxhalli/Form3-exercise/account"
+	"github.com/google/uuid"
+)
+
+func TestFaults(t *testing.T) {
+	retryPolicy := account.DefaultRetryPolicy()
+	retryPolicy.BaseDelay = time.Millisecond
+
+	subtests := []struct {
+		name     string
+		endpoint Endpoint
+		faults   []Fault
+		opts     []account.Option
+		ctx      func() (context.Context, context.CancelFunc)
+		expCalls int
+		check    func(t *testing.T, err error)
+	}{
+		{
+			name:     "5xx burst recovered through retries",
+			endpoint: EndpointFetch,
+			faults:   Repeat(2, Fault{StatusCode: http.StatusServiceUnavailable}),
+			opts:     []account.Option{account.WithRetryPolicy(retryPolicy)},
+			expCalls: 3,
+			check: func(t *testing.T, err error) {
+				if err != nil {
+					t.Errorf("expected nil error, got (%v)", err)
+				}
+			},
+		},
+		{
+			name:     "429 without retries",
+			endpoint: EndpointAny,
+			faults:   []Fault{{StatusCode: http.StatusTooManyRequests, RetryAfter: time.Second}},
+			expCalls: 1,
+			check: func(t *testing.T, err error) {
+				if !errors.Is(err, account.ErrRateLimited) {
+					t.Errorf("expected rate limited error, got (%v)", err)
+				}
+			},
+		},
+		{
+			name:     "Connection reset",
+			endpoint: EndpointFetch,
+			faults:   []Fault{{ResetConnection: true}},
+			// net/http transparently retries idempotent requests reset on a reused connection.
+			opts:     []account.Option{account.WithHttpClient(&http.Client{Transport: &http.Transport{DisableKeepAlives: true}})},
+			expCalls: 1,
+			check: func(t *testing.T, err error) {
+				var apiErr *account.ApiError
+				if err == nil || errors.As(err, &apiErr) {
+					t.Errorf("expected transport error, got (%v)", err)
+				}
+			},
+		},
+		{
+			name:     "Truncated body",
+			endpoint: EndpointFetch,
+			faults:   []Fault{{TruncateBody: true}},
+			expCalls: 1,
+			check: func(t *testing.T, err error) {
+				if err == nil || !strings.Contains(err.Error(), "EOF") {
+					t.Errorf("expected unexpected EOF error, got (%v)", err)
+				}
+			},
+		},
+		{
+			name:     "Malformed body",
+			endpoint: EndpointFetch,
+			faults:   []Fault{{MalformedBody: true}},
+			expCalls: 1,
+			check: func(t *testing.T, err error) {
+				if err == nil || !strings.Contains(err.Error(), "JSON") {
+					t.Errorf("expected JSON error, got (%v)", err)
+				}
+			},
+		},
+		{
+			name:     "Latency beyond deadline",
+			endpoint: EndpointFetch,
+			faults:   []Fault{{Latency: time.Second}},
+			ctx: func() (context.Context, context.CancelFunc) {
+				return context.WithTimeout(context.Background(), time.Duration(20)*time.Millisecond)
+			},
+			expCalls: 1,
+			check: func(t *testing.T, err error) {
+				if !errors.Is(err, context.DeadlineExceeded) {
+					t.Errorf("expected deadline exceeded, got (%v)", err)
+				}
+			},
+		},
+	}
+	for _, subtest := range subtests {
+		t.Run(subtest.name, func(t *testing.T) {
+			server := NewServer()
+			defer server.Close()
+			acc := newAccount("GB", "400300")
+			if _, err := server.Client().Create(acc); err != nil {
+				t.Fatalf("unexpected error (%v)", err)
+			}
+			server.ScriptFaults(subtest.endpoint, subtest.faults...)
+
+			ctx, cancel := context.Background(), context.CancelFunc(func() {})
+			if subtest.ctx != nil {
+				ctx, cancel = subtest.ctx()
+			}
+			defer cancel()
+			_, err := server.Client(subtest.opts...).FetchContext(ctx, uuid.MustParse(acc.Data.ID))
+			subtest.check(t, err)
+			if calls := server.Calls(EndpointFetch); calls != subtest.expCalls {
+				t.Errorf("expected (%d) fetch calls, got (%d)", subtest.expCalls, calls)
+			}
+		})
+	}
+}
+
+func TestWrongStatusCodeOnCreate(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	server.ScriptFaults(EndpointCreate, Fault{WrongStatusCode: http.StatusOK})
+
+	_, err := server.Client().Create(newAccount("GB", "400300"))
+	var apiErr *account.ApiError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusOK {
+		t.Errorf("expected ApiError with status 200, got (%v)", err)
+	}
+	if len(server.Accounts()) != 1 {
+		t.Errorf("expected the account to be created despite the wrong status code")
+	}
+}
+
+func TestFaultPrecedence(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	server.SetFault(EndpointAny, Fault{StatusCode: http.StatusBadGateway})
+	server.ScriptFaults(EndpointList, Fault{StatusCode: http.StatusServiceUnavailable})
+
+	client := server.Client()
+	subtests := []struct {
+		name      string
+		expStatus int
+	}{
+		{"Scripted endpoint fault first", http.StatusServiceUnavailable},
+		{"Persistent fault once the script is exhausted", http.StatusBadGateway},
+	}
+	for _, subtest := range subtests {
+		_, err := client.List(account.ListOptions{})
+		var apiErr *account.ApiError
+		if !errors.As(err, &apiErr) || apiErr.StatusCode != subtest.expStatus {
+			t.Errorf("%s: expected status (%d), got (%v)", subtest.name, subtest.expStatus, err)
+		}
+	}
+
+	server.ClearFaults()
+	if _, err := client.List(account.ListOptions{}); err != nil {
+		t.Errorf("expected nil error after clearing faults, got (%v)", err)
+	}
+	if server.Calls(EndpointList) != 3 || server.Calls(EndpointAny) != 3 {
+		t.Errorf("expected 3 list calls, got (%d) and (%d) in total", server.Calls(EndpointList), server.Calls(EndpointAny))
+	}
+}
@@ -0,0 +1,394 @@
+// Package accounttest provides an in-process fake of the form3 organisation accounts API,
+// so that code using package account can be tested without the docker-compose stack.
+//
+//	server := accounttest.NewServer()
+//	defer server.Close()
+//	client := server.Client()
+//	res, err := client.Create(acc)
+package accounttest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/edihoxhalli/Form3-exercise/account"
+	"github.com/google/uuid"
+)
+
+const (
+	// ApiVersion is the api version served by the fake.
+	ApiVersion = "v1/"
+	// DefaultPageSize is the page size used by list requests without page[size].
+	DefaultPageSize = 100
+	accountsPath    = "/" + ApiVersion + "organisation/accounts"
+	contentType     = "application/vnd.api+json"
+)
+
+// record is an account stored by the fake. Attributes are kept as a generic map,
+// so that attributes unknown to package account survive a round trip.
+type record struct {
+	ID             string
+	OrganisationID string
+	Type           string
+	Version        int64
+	Attributes     map[string]interface{}
+}
+
+func (r *record) data() map[string]interface{} {
+	return map[string]interface{}{
+		"id":              r.ID,
+		"organisation_id": r.OrganisationID,
+		"type":            r.Type,
+		"version":         r.Version,
+		"attributes":      r.Attributes,
+	}
+}
+
+// Server is a fake form3 organisation accounts API, storing accounts in memory.
+// It implements create, fetch, update, delete and paginated, filtered list,
+// with version checking, duplicate id conflicts and JSON:API error bodies.
+// Faults can be injected on demand through ScriptFaults and SetFault.
+// It is safe for concurrent use.
+type Server struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	accounts map[string]*record
+	order    []string
+
+	scripted   map[Endpoint][]Fault
+	persistent map[Endpoint]Fault
+	calls      map[Endpoint]int
+}
+
+// NewServer starts and returns a new Server. The caller should call Close when finished.
+func NewServer() *Server {
+	s := &Server{
+		accounts:   make(map[string]*record),
+		scripted:   make(map[Endpoint][]Fault),
+		persistent: make(map[Endpoint]Fault),
+		calls:      make(map[Endpoint]int),
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveWithFaults))
+	return s
+}
+
+// Host returns the value to use as account.Host, or with account.WithHost, to reach the Server.
+func (s *Server) Host() string {
+	return s.URL + "/"
+}
+
+// Client returns an account.Client reaching the Server, configured with the given options.
+func (s *Server) Client(opts ...account.Option) *account.Client {
+	return account.NewClient(append([]account.Option{
+		account.WithHost(s.Host()),
+		account.WithApiVersion(ApiVersion),
+	}, opts...)...)
+}
+
+// Accounts returns a snapshot of the stored accounts, in creation order.
+func (s *Server) Accounts() []account.AccountData {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	accounts := make([]account.AccountData, 0, len(s.order))
+	for _, id := range s.order {
+		accounts = append(accounts, toAccountData(s.accounts[id]))
+	}
+	return accounts
+}
+
+// Reset removes every stored account.
+func (s *Server) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.accounts = make(map[string]*record)
+	s.order = nil
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path == accountsPath {
+		switch r.Method {
+		case http.MethodPost:
+			s.create(w, r)
+		case http.MethodGet:
+			s.list(w, r)
+		default:
+			writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		}
+		return
+	}
+	id := strings.TrimPrefix(r.URL.Path, accountsPath+"/")
+	if id == r.URL.Path || strings.Contains(id, "/") {
+		writeError(w, http.StatusNotFound, "not found")
+		return
+	}
+	if _, err := uuid.Parse(id); err != nil {
+		writeError(w, http.StatusBadRequest, "id is not a valid uuid")
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+		s.fetch(w, id)
+	case http.MethodPatch:
+		s.update(w, r, id)
+	case http.MethodDelete:
+		s.delete(w, r, id)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+func (s *Server) create(w http.ResponseWriter, r *http.Request) {
+	rec, msg := decodeRecord(r)
+	if msg == "" {
+		msg = validateRecord(rec)
+	}
+	if msg != "" {
+		writeError(w, http.StatusBadRequest, msg)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, exists := s.accounts[rec.ID]; exists {
+		writeError(w, http.StatusConflict, "Account cannot be created as it violates a duplicate constraint")
+		return
+	}
+	rec.Version = 0
+	s.accounts[rec.ID] = rec
+	s.order = append(s.order, rec.ID)
+	writeData(w, http.StatusCreated, rec.data(), map[string]string{"self": accountsPath + "/" + rec.ID})
+}
+
+func (s *Server) fetch(w http.ResponseWriter, id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	rec, ok := s.accounts[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("record %s does not exist", id))
+		return
+	}
+	writeData(w, http.StatusOK, rec.data(), map[string]string{"self": accountsPath + "/" + id})
+}
+
+func (s *Server) update(w http.ResponseWriter, r *http.Request, id string) {
+	patch, msg := decodeRecord(r)
+	if msg != "" {
+		writeError(w, http.StatusBadRequest, msg)
+		return
+	}
+	if patch.ID != "" && patch.ID != id {
+		writeError(w, http.StatusBadRequest, "id in body does not match id in url")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	rec, ok := s.accounts[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("record %s does not exist", id))
+		return
+	}
+	if patch.Version != rec.Version {
+		writeError(w, http.StatusConflict, "invalid version")
+		return
+	}
+	for name, value := range patch.Attributes {
+		rec.Attributes[name] = value
+	}
+	rec.Version++
+	writeData(w, http.StatusOK, rec.data(), map[string]string{"self": accountsPath + "/" + id})
+}
+
+func (s *Server) delete(w http.ResponseWriter, r *http.Request, id string) {
+	version, err := strconv.ParseInt(r.URL.Query().Get("version"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "version is required and must be an integer")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	rec, ok := s.accounts[id]
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	if rec.Version != version {
+		writeError(w, http.StatusConflict, "invalid version")
+		return
+	}
+	delete(s.accounts, id)
+	for i, stored := range s.order {
+		if stored == id {
+			s.order = append(s.order[:i], s.order[i+1:]...)
+			break
+		}
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) list(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	pageSize := DefaultPageSize
+	if v := query.Get("page[size]"); v != "" {
+		size, err := strconv.Atoi(v)
+		if err != nil || size < 1 {
+			writeError(w, http.StatusBadRequest, "page[size] must be a positive integer")
+			return
+		}
+		pageSize = size
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var matching []*record
+	for _, id := range s.order {
+		if matches(s.accounts[id], query) {
+			matching = append(matching, s.accounts[id])
+		}
+	}
+	lastPage := 0
+	if len(matching) > 0 {
+		lastPage = (len(matching) - 1) / pageSize
+	}
+	pageNumber := 0
+	switch v := query.Get("page[number]"); v {
+	case "", "first":
+	case "last":
+		pageNumber = lastPage
+	default:
+		number, err := strconv.Atoi(v)
+		if err != nil || number < 0 {
+			writeError(w, http.StatusBadRequest, "page[number] must be a non negative integer, first or last")
+			return
+		}
+		pageNumber = number
+	}
+
+	data := []interface{}{}
+	for i := pageNumber * pageSize; i < len(matching) && i < (pageNumber+1)*pageSize; i++ {
+		data = append(data, matching[i].data())
+	}
+	links := map[string]string{
+		"self":  pageLink(query, pageNumber, pageSize),
+		"first": pageLink(query, 0, pageSize),
+		"last":  pageLink(query, lastPage, pageSize),
+	}
+	if pageNumber < lastPage {
+		links["next"] = pageLink(query, pageNumber+1, pageSize)
+	}
+	if pageNumber > 0 {
+		links["prev"] = pageLink(query, pageNumber-1, pageSize)
+	}
+	writeData(w, http.StatusOK, data, links)
+}
+
+// matches reports whether rec satisfies every filter[...] parameter of query.
+func matches(rec *record, query url.Values) bool {
+	for key, values := range query {
+		if !strings.HasPrefix(key, "filter[") || !strings.HasSuffix(key, "]") {
+			continue
+		}
+		attribute := key[len("filter[") : len(key)-1]
+		value, ok := rec.Attributes[attribute]
+		if !ok {
+			return false
+		}
+		found := false
+		for _, accepted := range strings.Split(values[0], ",") {
+			found = found || accepted == fmt.Sprint(value)
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+func pageLink(query url.Values, pageNumber, pageSize int) string {
+	q := url.Values{}
+	for key, values := range query {
+		q[key] = values
+	}
+	q.Set("page[number]", strconv.Itoa(pageNumber))
+	q.Set("page[size]", strconv.Itoa(pageSize))
+	return accountsPath + "?" + q.Encode()
+}
+
+func decodeRecord(r *http.Request) (*record, string) {
+	var body struct {
+		Data *struct {
+			ID             string                 `json:"id"`
+			OrganisationID string                 `json:"organisation_id"`
+			Type           string                 `json:"type"`
+			Version        int64                  `json:"version"`
+			Attributes     map[string]interface{} `json:"attributes"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		return nil, "invalid json body: " + err.Error()
+	}
+	if body.Data == nil {
+		return nil, "data is required"
+	}
+	rec := &record{
+		ID:             body.Data.ID,
+		OrganisationID: body.Data.OrganisationID,
+		Type:           body.Data.Type,
+		Version:        body.Data.Version,
+		Attributes:     body.Data.Attributes,
+	}
+	if rec.Attributes == nil {
+		rec.Attributes = make(map[string]interface{})
+	}
+	return rec, ""
+}
+
+func validateRecord(rec *record) string {
+	var violations []string
+	if _, err := uuid.Parse(rec.ID); err != nil {
+		violations = append(violations, "id in body must be of type uuid")
+	}
+	if _, err := uuid.Parse(rec.OrganisationID); err != nil {
+		violations = append(violations, "organisation_id in body must be of type uuid")
+	}
+	if rec.Type != "accounts" {
+		violations = append(violations, "type in body should be one of [accounts]")
+	}
+	if country, _ := rec.Attributes["country"].(string); country == "" {
+		violations = append(violations, "country in body is required")
+	}
+	if len(violations) == 0 {
+		return ""
+	}
+	return "validation failure list:\n" + strings.Join(violations, "\n")
+}
+
+func toAccountData(rec *record) account.AccountData {
+	var data account.AccountData
+	raw, _ := json.Marshal(rec.data())
+	json.Unmarshal(raw, &data)
+	return data
+}
+
+func writeData(w http.ResponseWriter, statusCode int, data interface{}, links map[string]string) {
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(map[string]interface{}{"data": data, "links": links})
+}
+
+func writeError(w http.ResponseWriter, statusCode int, message string) {
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(map[string]string{
+		"error_message": message,
+		"error_code":    uuid.NewSHA1(uuid.NameSpaceOID, []byte(message)).String(),
+	})
+}
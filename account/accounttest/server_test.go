@@ -0,0 +1,157 @@
+package accounttest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/edihoxhalli/Form3-exercise/account"
+	"github.com/google/uuid"
+)
+
+func newAccount(country, bankID string) account.Account {
+	return account.Account{
+		Data: &account.AccountData{
+			ID:             uuid.NewString(),
+			OrganisationID: "eb0bd6f5-c3f5-44b2-b677-acd23cdde73c",
+			Type:           "accounts",
+			Attributes: &account.AccountAttributes{
+				Country: &country,
+				BankID:  bankID,
+				Name:    []string{"Samantha Holder"},
+			},
+		},
+	}
+}
+
+func TestServerLifecycle(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	client := server.Client()
+	acc := newAccount("GB", "400300")
+	id := uuid.MustParse(acc.Data.ID)
+
+	created, err := client.Create(acc)
+	if err != nil {
+		t.Fatalf("expected nil error on create, got (%v)", err)
+	}
+	if created.StatusCode != http.StatusCreated || *created.ResponseBody.Data.Version != 0 {
+		t.Errorf("expected created account with version 0, got (%+v)", created.ResponseBody.Data)
+	}
+
+	_, err = client.Create(acc)
+	if !errors.Is(err, account.ErrConflict) {
+		t.Errorf("expected conflict on duplicate create, got (%v)", err)
+	}
+
+	fetched, err := client.Fetch(id)
+	if err != nil {
+		t.Fatalf("expected nil error on fetch, got (%v)", err)
+	}
+	if !reflect.DeepEqual(fetched.ResponseBody.Data.Attributes, acc.Data.Attributes) {
+		t.Errorf("expected attributes (%+v), got (%+v)", acc.Data.Attributes, fetched.ResponseBody.Data.Attributes)
+	}
+
+	version := int64(0)
+	updated, err := client.Update(account.Account{Data: &account.AccountData{
+		ID: acc.Data.ID, Version: &version, Attributes: &account.AccountAttributes{Name: []string{"Sam Holder"}},
+	}})
+	if err != nil {
+		t.Fatalf("expected nil error on update, got (%v)", err)
+	}
+	if *updated.ResponseBody.Data.Version != 1 || updated.ResponseBody.Data.Attributes.Name[0] != "Sam Holder" ||
+		updated.ResponseBody.Data.Attributes.BankID != "400300" {
+		t.Errorf("expected merged attributes with version 1, got (%+v)", updated.ResponseBody.Data)
+	}
+
+	_, err = client.Delete(id, 0)
+	var conflictErr *account.VersionConflictError
+	if !errors.As(err, &conflictErr) {
+		t.Errorf("expected version conflict on delete with stale version, got (%v)", err)
+	}
+	if _, err := client.Delete(id, 1); err != nil {
+		t.Errorf("expected nil error on delete, got (%v)", err)
+	}
+	_, err = client.Fetch(id)
+	var apiErr *account.ApiError
+	if !errors.Is(err, account.ErrNotFound) || !errors.As(err, &apiErr) || apiErr.ErrorMessage == "" {
+		t.Errorf("expected not found with error message after delete, got (%v)", err)
+	}
+}
+
+func TestServerValidation(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	acc := newAccount("", "400300")
+	acc.Data.Type = "account"
+
+	_, err := server.Client().Create(acc)
+	var apiErr *account.ApiError
+	if !errors.Is(err, account.ErrValidation) || !errors.As(err, &apiErr) {
+		t.Fatalf("expected validation error, got (%v)", err)
+	}
+	expMessage := "validation failure list:\ntype in body should be one of [accounts]\ncountry in body is required"
+	if apiErr.ErrorMessage != expMessage {
+		t.Errorf("expected error message (%s), got (%s)", expMessage, apiErr.ErrorMessage)
+	}
+	if len(server.Accounts()) != 0 {
+		t.Errorf("expected no stored account, got (%d)", len(server.Accounts()))
+	}
+}
+
+func TestServerList(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	client := server.Client()
+	for i := 0; i < 5; i++ {
+		country := "GB"
+		if i%2 == 1 {
+			country = "FR"
+		}
+		if _, err := client.Create(newAccount(country, "400300")); err != nil {
+			t.Fatalf("unexpected error (%v)", err)
+		}
+	}
+
+	subtests := []struct {
+		name     string
+		opts     account.ListOptions
+		expCount int
+		expNext  bool
+	}{
+		{"First page", account.ListOptions{PageSize: 2}, 2, true},
+		{"Last page", account.ListOptions{PageNumber: 2, PageSize: 2}, 1, false},
+		{"Filtered by country", account.ListOptions{Filter: account.ListFilter{Country: "FR"}}, 2, false},
+		{"Filtered by unknown customer", account.ListOptions{Filter: account.ListFilter{CustomerID: "1"}}, 0, false},
+	}
+	for _, subtest := range subtests {
+		t.Run(subtest.name, func(t *testing.T) {
+			res, err := client.List(subtest.opts)
+			if err != nil {
+				t.Fatalf("unexpected error (%v)", err)
+			}
+			if len(res.ResponseBody.Data) != subtest.expCount {
+				t.Errorf("expected (%d) accounts, got (%d)", subtest.expCount, len(res.ResponseBody.Data))
+			}
+			if (res.ResponseBody.Links.Next != "") != subtest.expNext {
+				t.Errorf("expected next link presence %v, got (%s)", subtest.expNext, res.ResponseBody.Links.Next)
+			}
+		})
+	}
+
+	it := client.Iterate(context.Background(), account.ListOptions{PageSize: 2})
+	var ids []string
+	for it.Next() {
+		ids = append(ids, it.Account().ID)
+	}
+	if it.Err() != nil || len(ids) != 5 {
+		t.Fatalf("expected to iterate over 5 accounts, got (%d) and error (%v)", len(ids), it.Err())
+	}
+	for i, stored := range server.Accounts() {
+		if ids[i] != stored.ID {
+			t.Errorf("expected account (%s) at position %d, got (%s)", stored.ID, i, ids[i])
+		}
+	}
+}
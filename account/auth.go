@@ -0,0 +1,26 @@
+package account
+
+import "net/http"
+
+// Authenticator authenticates the requests sent by a Client, e.g. by signing them
+// or by adding credentials to their headers.
+// Authenticate is called before every attempt of a request, including retries,
+// and must be safe for concurrent use.
+type Authenticator interface {
+	Authenticate(req *http.Request) error
+}
+
+// AuthenticatorFunc is an adapter allowing the use of ordinary functions as Authenticator.
+type AuthenticatorFunc func(req *http.Request) error
+
+func (f AuthenticatorFunc) Authenticate(req *http.Request) error {
+	return f(req)
+}
+
+// WithAuthenticator sets the Authenticator of the Client.
+// By default requests are sent unauthenticated, which is only accepted by the local interview API.
+func WithAuthenticator(authenticator Authenticator) Option {
+	return func(c *Client) {
+		c.authenticator = authenticator
+	}
+}
@@ -0,0 +1,224 @@
+package account
+
+import (
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// CircuitState is the state of a CircuitBreaker.
+type CircuitState int
+
+const (
+	// CircuitClosed lets every request through, while counting failures.
+	CircuitClosed CircuitState = iota
+	// CircuitOpen rejects every request with ErrCircuitOpen.
+	CircuitOpen
+	// CircuitHalfOpen lets a limited number of probe requests through,
+	// closing the circuit when they succeed, and opening it again when one fails.
+	CircuitHalfOpen
+)
+
+func (s CircuitState) String() string {
+	return [...]string{"closed", "open", "half-open"}[s]
+}
+
+// CircuitBreakerConfig configures a CircuitBreaker. Zero fields fall back to their defaults.
+type CircuitBreakerConfig struct {
+	// Window is the rolling window over which failures are counted. Defaults to 10s.
+	Window time.Duration
+	// MinRequests is the number of requests within Window below which the circuit never opens.
+	// Defaults to 5.
+	MinRequests int
+	// FailureRatio is the ratio of failed requests within Window, between 0 and 1,
+	// from which the circuit opens. Defaults to 0.5.
+	FailureRatio float64
+	// OpenTimeout is how long the circuit stays open before letting probe requests through.
+	// Defaults to 5s.
+	OpenTimeout time.Duration
+	// HalfOpenRequests is the number of concurrent probe requests let through when half-open.
+	// Defaults to 1.
+	HalfOpenRequests int
+	// IsFailure reports whether a request failed, from its status code, or 0 if there was no
+	// response, and its transport error. Defaults to transport errors and 5xx responses.
+	// Canceled requests are never counted.
+	IsFailure func(statusCode int, err error) bool
+	// OnStateChange, when set, is called on every change of state, e.g. to alert.
+	// It is called synchronously, outside of any lock.
+	OnStateChange func(from, to CircuitState)
+}
+
+// CircuitBreaker stops a Client from calling the form3 API while it is failing, so that
+// callers fail fast with ErrCircuitOpen instead of waiting for every request to time out.
+// Every attempt of a request goes through the CircuitBreaker.
+// The same CircuitBreaker can be shared by several Clients calling the same form3 API.
+// It is safe for concurrent use.
+type CircuitBreaker struct {
+	config CircuitBreakerConfig
+
+	mu       sync.Mutex
+	state    CircuitState
+	openedAt time.Time
+	outcomes []outcome
+	probes   int
+	// generation is incremented on every change of state, so that the results of requests
+	// let through before it are not accounted for in the new state.
+	generation uint64
+}
+
+// circuitTicket identifies a request let through by allow, to be given back to record.
+type circuitTicket struct {
+	generation uint64
+	probe      bool
+}
+
+type outcome struct {
+	at     time.Time
+	failed bool
+}
+
+// NewCircuitBreaker returns a closed CircuitBreaker configured with config.
+func NewCircuitBreaker(config CircuitBreakerConfig) *CircuitBreaker {
+	if config.Window <= 0 {
+		config.Window = time.Duration(10) * time.Second
+	}
+	if config.MinRequests < 1 {
+		config.MinRequests = 5
+	}
+	if config.FailureRatio <= 0 || config.FailureRatio > 1 {
+		config.FailureRatio = 0.5
+	}
+	if config.OpenTimeout <= 0 {
+		config.OpenTimeout = time.Duration(5) * time.Second
+	}
+	if config.HalfOpenRequests < 1 {
+		config.HalfOpenRequests = 1
+	}
+	if config.IsFailure == nil {
+		config.IsFailure = isAvailabilityFailure
+	}
+	return &CircuitBreaker{config: config}
+}
+
+// WithCircuitBreaker makes the Client go through breaker before every attempt of a request.
+func WithCircuitBreaker(breaker *CircuitBreaker) Option {
+	return func(c *Client) {
+		c.circuitBreaker = breaker
+	}
+}
+
+// State returns the current state of the CircuitBreaker.
+func (b *CircuitBreaker) State() CircuitState {
+	b.mu.Lock()
+	from := b.state
+	b.expireOpen()
+	to := b.state
+	b.mu.Unlock()
+	b.notify(from, to)
+	return to
+}
+
+// allow returns ErrCircuitOpen if a request may not be sent.
+// Otherwise the caller must report the result of the request through record, with the returned ticket.
+func (b *CircuitBreaker) allow() (circuitTicket, error) {
+	b.mu.Lock()
+	from := b.state
+	b.expireOpen()
+	to := b.state
+	ticket := circuitTicket{generation: b.generation}
+	var err error
+	switch {
+	case b.state == CircuitOpen:
+		err = ErrCircuitOpen
+	case b.state == CircuitHalfOpen && b.probes >= b.config.HalfOpenRequests:
+		err = ErrCircuitOpen
+	case b.state == CircuitHalfOpen:
+		b.probes++
+		ticket.probe = true
+	}
+	b.mu.Unlock()
+	b.notify(from, to)
+	return ticket, err
+}
+
+// record reports the result of a request let through by allow with ticket.
+// Results of requests let through before the last change of state are ignored.
+func (b *CircuitBreaker) record(ticket circuitTicket, statusCode int, err error) {
+	var canceledErr *RequestCanceledError
+	canceled := errors.As(err, &canceledErr)
+	failed := !canceled && b.config.IsFailure(statusCode, err)
+
+	b.mu.Lock()
+	from := b.state
+	now := timeNow()
+	if ticket.generation != b.generation {
+		b.mu.Unlock()
+		return
+	}
+	switch b.state {
+	case CircuitHalfOpen:
+		if !ticket.probe {
+			break
+		}
+		b.probes--
+		if failed {
+			b.open(now)
+		} else if !canceled {
+			b.state, b.outcomes = CircuitClosed, nil
+			b.generation++
+		}
+	case CircuitClosed:
+		if canceled {
+			break
+		}
+		b.outcomes = append(b.outcomes, outcome{now, failed})
+		b.prune(now)
+		failures := 0
+		for _, o := range b.outcomes {
+			if o.failed {
+				failures++
+			}
+		}
+		if len(b.outcomes) >= b.config.MinRequests &&
+			float64(failures) >= b.config.FailureRatio*float64(len(b.outcomes)) {
+			b.open(now)
+		}
+	}
+	to := b.state
+	b.mu.Unlock()
+	b.notify(from, to)
+}
+
+// expireOpen moves an open circuit to half-open once OpenTimeout is over. It must be called with mu held.
+func (b *CircuitBreaker) expireOpen() {
+	if b.state == CircuitOpen && timeNow().Sub(b.openedAt) >= b.config.OpenTimeout {
+		b.state, b.probes = CircuitHalfOpen, 0
+		b.generation++
+	}
+}
+
+// open opens the circuit. It must be called with mu held.
+func (b *CircuitBreaker) open(now time.Time) {
+	b.state, b.openedAt, b.outcomes = CircuitOpen, now, nil
+	b.generation++
+}
+
+// prune drops the outcomes older than Window. It must be called with mu held.
+func (b *CircuitBreaker) prune(now time.Time) {
+	i := 0
+	for i < len(b.outcomes) && now.Sub(b.outcomes[i].at) > b.config.Window {
+		i++
+	}
+	b.outcomes = b.outcomes[i:]
+}
+
+func (b *CircuitBreaker) notify(from, to CircuitState) {
+	if from != to && b.config.OnStateChange != nil {
+		b.config.OnStateChange(from, to)
+	}
+}
+
+func isAvailabilityFailure(statusCode int, err error) bool {
+	return err != nil || statusCode >= http.StatusInternalServerError
+}
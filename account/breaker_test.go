@@ -0,0 +1,176 @@
+package account
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	restoreStubs(t)
+	now := time.Date(2022, time.March, 1, 10, 0, 0, 0, time.UTC)
+	timeNow = func() time.Time { return now }
+
+	var failing int32 = 1
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		if atomic.LoadInt32(&failing) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	var changes []string
+	breaker := NewCircuitBreaker(CircuitBreakerConfig{
+		MinRequests: 3,
+		OpenTimeout: time.Duration(5) * time.Second,
+		OnStateChange: func(from, to CircuitState) {
+			changes = append(changes, from.String()+">"+to.String())
+		},
+	})
+	c := NewClient(WithHost(server.URL+"/"), WithCircuitBreaker(breaker), WithRetryPolicy(DefaultRetryPolicy()))
+	sleep = func(ctx context.Context, d time.Duration) error { return nil }
+	del := func() error {
+		_, err := c.Delete(uuid.New(), 0)
+		return err
+	}
+
+	if err := del(); !errors.Is(err, ErrServer) {
+		t.Fatalf("expected ErrServer, got (%v)", err)
+	}
+	if breaker.State() != CircuitOpen || calls != 3 {
+		t.Fatalf("expected the circuit to open after 3 failed attempts, got (%s) after %d calls", breaker.State(), calls)
+	}
+	if err := del(); err != ErrCircuitOpen {
+		t.Errorf("expected ErrCircuitOpen, got (%v)", err)
+	}
+	if calls != 3 {
+		t.Errorf("expected no call while the circuit is open, got %d", calls)
+	}
+
+	now = now.Add(time.Duration(5) * time.Second)
+	if breaker.State() != CircuitHalfOpen {
+		t.Fatalf("expected the circuit to be half-open after OpenTimeout, got (%s)", breaker.State())
+	}
+	policy := DefaultRetryPolicy()
+	policy.MaxAttempts = 1
+	c.retryPolicy = policy
+	if err := del(); !errors.Is(err, ErrServer) {
+		t.Errorf("expected the failed probe to return ErrServer, got (%v)", err)
+	}
+	if breaker.State() != CircuitOpen {
+		t.Errorf("expected a failed probe to open the circuit again, got (%s)", breaker.State())
+	}
+
+	now = now.Add(time.Duration(5) * time.Second)
+	atomic.StoreInt32(&failing, 0)
+	if err := del(); err != nil {
+		t.Errorf("unexpected error (%v)", err)
+	}
+	if breaker.State() != CircuitClosed {
+		t.Errorf("expected a successful probe to close the circuit, got (%s)", breaker.State())
+	}
+	expected := []string{"closed>open", "open>half-open", "half-open>open", "open>half-open", "half-open>closed"}
+	if !reflect.DeepEqual(changes, expected) {
+		t.Errorf("expected state changes (%v), got (%v)", expected, changes)
+	}
+}
+
+func TestCircuitBreakerRollingWindow(t *testing.T) {
+	restoreStubs(t)
+	now := time.Date(2022, time.March, 1, 10, 0, 0, 0, time.UTC)
+	timeNow = func() time.Time { return now }
+	breaker := NewCircuitBreaker(CircuitBreakerConfig{Window: time.Second, MinRequests: 2, FailureRatio: 0.5})
+	record := func(statusCode int, err error) {
+		ticket, _ := breaker.allow()
+		breaker.record(ticket, statusCode, err)
+	}
+
+	record(0, errors.New("connection refused"))
+	now = now.Add(time.Duration(2) * time.Second)
+	record(http.StatusOK, nil)
+	if breaker.State() != CircuitClosed {
+		t.Errorf("expected failures out of the window to be ignored, got (%s)", breaker.State())
+	}
+	record(http.StatusNotFound, nil)
+	record(0, &RequestCanceledError{context.Canceled})
+	if breaker.State() != CircuitClosed {
+		t.Errorf("expected client errors and cancellations not to count as failures, got (%s)", breaker.State())
+	}
+	record(http.StatusInternalServerError, nil)
+	if breaker.State() != CircuitClosed {
+		t.Errorf("expected the circuit to stay closed below a failure ratio of 0.5, got (%s)", breaker.State())
+	}
+	record(http.StatusBadGateway, nil)
+	if breaker.State() != CircuitOpen {
+		t.Errorf("expected the circuit to open at a failure ratio of 0.5, got (%s)", breaker.State())
+	}
+}
+
+func TestCircuitBreakerHalfOpenProbes(t *testing.T) {
+	restoreStubs(t)
+	now := time.Date(2022, time.March, 1, 10, 0, 0, 0, time.UTC)
+	timeNow = func() time.Time { return now }
+	breaker := NewCircuitBreaker(CircuitBreakerConfig{MinRequests: 1, HalfOpenRequests: 2})
+
+	ticket, _ := breaker.allow()
+	breaker.record(ticket, http.StatusBadGateway, nil)
+	now = now.Add(breaker.config.OpenTimeout)
+	for i := 0; i < 2; i++ {
+		if _, err := breaker.allow(); err != nil {
+			t.Errorf("expected probe %d to be allowed, got (%v)", i+1, err)
+		}
+	}
+	if _, err := breaker.allow(); err != ErrCircuitOpen {
+		t.Errorf("expected ErrCircuitOpen beyond HalfOpenRequests, got (%v)", err)
+	}
+}
+
+func TestCircuitBreakerStaleResults(t *testing.T) {
+	restoreStubs(t)
+	now := time.Date(2022, time.March, 1, 10, 0, 0, 0, time.UTC)
+	timeNow = func() time.Time { return now }
+	breaker := NewCircuitBreaker(CircuitBreakerConfig{MinRequests: 1})
+
+	slowSuccess, _ := breaker.allow()
+	slowFailure, _ := breaker.allow()
+	failure, _ := breaker.allow()
+	breaker.record(failure, http.StatusBadGateway, nil)
+	now = now.Add(breaker.config.OpenTimeout)
+	probe, err := breaker.allow()
+	if err != nil || !probe.probe {
+		t.Fatalf("expected a probe to be allowed, got (%+v) and error (%v)", probe, err)
+	}
+
+	breaker.record(slowSuccess, http.StatusOK, nil)
+	breaker.record(slowFailure, http.StatusBadGateway, nil)
+	if breaker.State() != CircuitHalfOpen {
+		t.Errorf("expected results of requests sent before the circuit opened to be ignored, got (%s)", breaker.State())
+	}
+	if _, err := breaker.allow(); err != ErrCircuitOpen {
+		t.Errorf("expected the probe to be still in flight, got (%v)", err)
+	}
+	breaker.record(circuitTicket{generation: probe.generation}, http.StatusOK, nil)
+	if breaker.State() != CircuitHalfOpen {
+		t.Errorf("expected results of non probe requests to be ignored while half-open, got (%s)", breaker.State())
+	}
+
+	breaker.record(probe, http.StatusOK, nil)
+	if breaker.State() != CircuitClosed {
+		t.Errorf("expected the probe to close the circuit, got (%s)", breaker.State())
+	}
+	breaker.record(probe, http.StatusBadGateway, nil)
+	if breaker.State() != CircuitClosed {
+		t.Errorf("expected a result recorded twice to be ignored, got (%s)", breaker.State())
+	}
+}
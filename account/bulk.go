@@ -0,0 +1,215 @@
+package account
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+
+	"github.com/google/uuid"
+)
+
+// DefaultBulkConcurrency is the number of concurrent requests of a bulk operation
+// without BulkOptions.Concurrency.
+const DefaultBulkConcurrency = 8
+
+// ErrBulkAborted is the error of the items of a fail-fast bulk operation
+// which were not started because a previous item failed.
+var ErrBulkAborted = errors.New("ACCOUNT BULK OPERATION ABORTED")
+
+// BulkOptions configures a bulk operation such as CreateMany or DeleteMany.
+type BulkOptions struct {
+	// Concurrency is the maximum number of items processed concurrently.
+	// Defaults to DefaultBulkConcurrency.
+	Concurrency int
+	// Ordered streams the results in input order, instead of as they complete.
+	Ordered bool
+	// FailFast stops starting new items once an item failed. The items in flight complete,
+	// while the ones not started yet get ErrBulkAborted. By default every item is processed,
+	// whatever the errors of the others.
+	FailFast bool
+}
+
+// BulkResult is the result of an item of a bulk operation.
+// Index is the position of the item in the input, starting from 0.
+// Response and Err are the results of the operation on Input.
+type BulkResult[T any] struct {
+	Index    int
+	Input    T
+	Response *AccountApiResponse
+	Err      error
+}
+
+// DeleteRequest identifies an Account record to delete through DeleteMany.
+type DeleteRequest struct {
+	ID      uuid.UUID
+	Version int64
+}
+
+// CreateMany creates accounts on the form3 API using the default Client.
+// See Client.CreateMany for more information.
+func CreateMany(ctx context.Context, accounts []Account, opts BulkOptions) <-chan BulkResult[Account] {
+	return defaultClient().CreateMany(ctx, accounts, opts)
+}
+
+// CreateManyFrom is like CreateMany but reads the accounts from a channel.
+// See Client.CreateManyFrom for more information.
+func CreateManyFrom(ctx context.Context, accounts <-chan Account, opts BulkOptions) <-chan BulkResult[Account] {
+	return defaultClient().CreateManyFrom(ctx, accounts, opts)
+}
+
+// DeleteMany deletes Account records on the form3 API using the default Client.
+// See Client.DeleteMany for more information.
+func DeleteMany(ctx context.Context, deletes []DeleteRequest, opts BulkOptions) <-chan BulkResult[DeleteRequest] {
+	return defaultClient().DeleteMany(ctx, deletes, opts)
+}
+
+// DeleteManyFrom is like DeleteMany but reads the Account records to delete from a channel.
+// See Client.DeleteManyFrom for more information.
+func DeleteManyFrom(ctx context.Context, deletes <-chan DeleteRequest, opts BulkOptions) <-chan BulkResult[DeleteRequest] {
+	return defaultClient().DeleteManyFrom(ctx, deletes, opts)
+}
+
+// CreateMany creates accounts on the form3 API through CreateContext, with the concurrency of opts.
+// It returns a channel streaming one BulkResult per account, closed once every account is processed.
+// The caller must receive every result, otherwise the bulk operation blocks.
+// Once ctx is done, the accounts not started yet get a *RequestCanceledError.
+func (c *Client) CreateMany(ctx context.Context, accounts []Account, opts BulkOptions) <-chan BulkResult[Account] {
+	return runBulk(ctx, sliceChan(accounts), true, opts, c.CreateContext)
+}
+
+// CreateManyFrom is like CreateMany but reads the accounts from a channel, until it is closed or ctx is done.
+// Once ctx is done, the accounts not read yet get no BulkResult, as the channel may never be closed.
+func (c *Client) CreateManyFrom(ctx context.Context, accounts <-chan Account, opts BulkOptions) <-chan BulkResult[Account] {
+	return runBulk(ctx, accounts, false, opts, c.CreateContext)
+}
+
+// DeleteMany deletes Account records on the form3 API through DeleteContext, with the concurrency of opts.
+// It returns a channel streaming one BulkResult per record, closed once every record is processed.
+// The caller must receive every result, otherwise the bulk operation blocks.
+// Once ctx is done, the records not started yet get a *RequestCanceledError.
+func (c *Client) DeleteMany(ctx context.Context, deletes []DeleteRequest, opts BulkOptions) <-chan BulkResult[DeleteRequest] {
+	return runBulk(ctx, sliceChan(deletes), true, opts, c.deleteRequest)
+}
+
+// DeleteManyFrom is like DeleteMany but reads the Account records to delete from a channel,
+// until it is closed or ctx is done. As for CreateManyFrom, the records not read yet once ctx is done
+// get no BulkResult.
+func (c *Client) DeleteManyFrom(ctx context.Context, deletes <-chan DeleteRequest, opts BulkOptions) <-chan BulkResult[DeleteRequest] {
+	return runBulk(ctx, deletes, false, opts, c.deleteRequest)
+}
+
+func (c *Client) deleteRequest(ctx context.Context, d DeleteRequest) (*AccountApiResponse, error) {
+	return c.DeleteContext(ctx, d.ID, d.Version)
+}
+
+func sliceChan[T any](items []T) <-chan T {
+	ch := make(chan T)
+	go func() {
+		defer close(ch)
+		for _, item := range items {
+			ch <- item
+		}
+	}()
+	return ch
+}
+
+// runBulk applies do to every input with a pool of workers, and streams the results.
+// Every input is read, so that the sender of inputs never blocks, even once aborted.
+// Once ctx is done, the inputs not started yet get a *RequestCanceledError. Unless inputs is
+// bounded, i.e. always closed after its last item, reading then stops, so that a channel
+// which is never closed does not keep the bulk operation running.
+func runBulk[T any](ctx context.Context, inputs <-chan T, bounded bool, opts BulkOptions,
+	do func(ctx context.Context, input T) (*AccountApiResponse, error)) <-chan BulkResult[T] {
+	concurrency := opts.Concurrency
+	if concurrency < 1 {
+		concurrency = DefaultBulkConcurrency
+	}
+	jobs := make(chan BulkResult[T])
+	completed := make(chan BulkResult[T])
+	var aborted atomic.Bool
+	var wg sync.WaitGroup
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		defer close(jobs)
+		for index := 0; bounded || ctx.Err() == nil; index++ {
+			var input T
+			var ok bool
+			if bounded {
+				input, ok = <-inputs
+			} else {
+				select {
+				case <-ctx.Done():
+					return
+				case input, ok = <-inputs:
+				}
+			}
+			if !ok {
+				return
+			}
+			item := BulkResult[T]{Index: index, Input: input}
+			switch {
+			case ctx.Err() != nil:
+				item.Err = &RequestCanceledError{ctx.Err()}
+				completed <- item
+				continue
+			case aborted.Load():
+				item.Err = ErrBulkAborted
+				completed <- item
+				continue
+			}
+			select {
+			case jobs <- item:
+			case <-ctx.Done():
+				item.Err = &RequestCanceledError{ctx.Err()}
+				completed <- item
+			}
+		}
+	}()
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for item := range jobs {
+				if aborted.Load() {
+					item.Err = ErrBulkAborted
+				} else {
+					item.Response, item.Err = do(ctx, item.Input)
+					if item.Err != nil && opts.FailFast {
+						aborted.Store(true)
+					}
+				}
+				completed <- item
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(completed)
+	}()
+
+	if !opts.Ordered {
+		return completed
+	}
+	results := make(chan BulkResult[T])
+	go func() {
+		defer close(results)
+		pending := make(map[int]BulkResult[T])
+		next := 0
+		for item := range completed {
+			pending[item.Index] = item
+			for {
+				ready, ok := pending[next]
+				if !ok {
+					break
+				}
+				results <- ready
+				delete(pending, next)
+				next++
+			}
+		}
+	}()
+	return results
+}
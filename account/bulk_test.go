@@ -0,0 +1,211 @@
+package account_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sort"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/edihoxhalli/Form3-exercise/account"
+	"github.com/edihoxhalli/Form3-exercise/account/accounttest"
+	"github.com/google/uuid"
+)
+
+func bulkAccounts(n int) []account.Account {
+	accounts := make([]account.Account, n)
+	for i := range accounts {
+		accounts[i] = testAccount(uuid.NewString())
+	}
+	return accounts
+}
+
+func TestCreateManyOrdered(t *testing.T) {
+	server := accounttest.NewServer()
+	defer server.Close()
+	server.SetFault(accounttest.EndpointCreate, accounttest.Fault{RandomLatency: time.Duration(5) * time.Millisecond})
+
+	var inFlight, maxInFlight int32
+	client := server.Client(account.WithMiddleware(func(next http.RoundTripper) http.RoundTripper {
+		return account.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			n := atomic.AddInt32(&inFlight, 1)
+			defer atomic.AddInt32(&inFlight, -1)
+			for max := atomic.LoadInt32(&maxInFlight); n > max && !atomic.CompareAndSwapInt32(&maxInFlight, max, n); {
+				max = atomic.LoadInt32(&maxInFlight)
+			}
+			return next.RoundTrip(req)
+		})
+	}))
+
+	accounts := bulkAccounts(20)
+	next := 0
+	for result := range client.CreateMany(context.Background(), accounts, account.BulkOptions{Concurrency: 4, Ordered: true}) {
+		if result.Index != next {
+			t.Errorf("expected result %d, got %d", next, result.Index)
+		}
+		if result.Err != nil || result.Response.StatusCode != http.StatusCreated || result.Input.Data.ID != accounts[next].Data.ID {
+			t.Errorf("unexpected result %d (%+v)", result.Index, result)
+		}
+		next++
+	}
+	if next != len(accounts) || len(server.Accounts()) != len(accounts) {
+		t.Errorf("expected %d created accounts, got %d results and %d stored", len(accounts), next, len(server.Accounts()))
+	}
+	if maxInFlight > 4 {
+		t.Errorf("expected at most 4 requests in flight, got %d", maxInFlight)
+	}
+}
+
+func TestCreateManyFromChannelContinueOnError(t *testing.T) {
+	server := accounttest.NewServer()
+	defer server.Close()
+	client := server.Client()
+
+	accounts := bulkAccounts(10)
+	accounts[3] = accounts[2]
+	inputs := make(chan account.Account)
+	go func() {
+		defer close(inputs)
+		for _, acc := range accounts {
+			inputs <- acc
+		}
+	}()
+
+	var indexes []int
+	failures := 0
+	for result := range client.CreateManyFrom(context.Background(), inputs, account.BulkOptions{Concurrency: 1}) {
+		indexes = append(indexes, result.Index)
+		if result.Err != nil {
+			failures++
+			if result.Index != 3 || !errors.Is(result.Err, account.ErrConflict) {
+				t.Errorf("unexpected error on result %d (%v)", result.Index, result.Err)
+			}
+		}
+	}
+	sort.Ints(indexes)
+	if len(indexes) != 10 || indexes[0] != 0 || indexes[9] != 9 || failures != 1 {
+		t.Errorf("expected 10 results with 1 failure, got (%v) with %d failures", indexes, failures)
+	}
+	if n := len(server.Accounts()); n != 9 {
+		t.Errorf("expected 9 stored accounts, got %d", n)
+	}
+}
+
+func TestCreateManyFailFast(t *testing.T) {
+	server := accounttest.NewServer()
+	defer server.Close()
+	client := server.Client()
+
+	accounts := bulkAccounts(10)
+	accounts[4] = accounts[3]
+	var results []account.BulkResult[account.Account]
+	for result := range client.CreateMany(context.Background(), accounts, account.BulkOptions{Concurrency: 1, Ordered: true, FailFast: true}) {
+		results = append(results, result)
+	}
+	if len(results) != 10 {
+		t.Fatalf("expected a result for every account, got %d", len(results))
+	}
+	for i, result := range results {
+		switch {
+		case i < 4 && result.Err != nil:
+			t.Errorf("unexpected error on result %d (%v)", i, result.Err)
+		case i == 4 && !errors.Is(result.Err, account.ErrConflict):
+			t.Errorf("expected ErrConflict on result 4, got (%v)", result.Err)
+		case i > 4 && result.Err != account.ErrBulkAborted:
+			t.Errorf("expected ErrBulkAborted on result %d, got (%v)", i, result.Err)
+		}
+	}
+	if n := len(server.Accounts()); n != 4 {
+		t.Errorf("expected 4 stored accounts, got %d", n)
+	}
+}
+
+func TestCreateManyFromCanceled(t *testing.T) {
+	server := accounttest.NewServer()
+	defer server.Close()
+	client := server.Client()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	accounts := make(chan account.Account)
+	results := client.CreateManyFrom(ctx, accounts, account.BulkOptions{Concurrency: 2, Ordered: true})
+	for _, acc := range bulkAccounts(2) {
+		accounts <- acc
+	}
+	for i := 0; i < 2; i++ {
+		if result := <-results; result.Err != nil {
+			t.Errorf("unexpected error on result %d (%v)", result.Index, result.Err)
+		}
+	}
+
+	cancel()
+	select {
+	case result, ok := <-results:
+		if ok {
+			t.Errorf("expected no result once canceled, got (%+v)", result)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("expected the results to be closed once canceled, without closing the input channel")
+	}
+}
+
+func TestCreateManyCanceled(t *testing.T) {
+	server := accounttest.NewServer()
+	defer server.Close()
+	client := server.Client()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	accounts := bulkAccounts(50)
+	seen := map[int]bool{}
+	canceled := 0
+	for result := range client.CreateMany(ctx, accounts, account.BulkOptions{Concurrency: 2}) {
+		if len(seen) == 0 {
+			cancel()
+		}
+		seen[result.Index] = true
+		var canceledErr *account.RequestCanceledError
+		if errors.As(result.Err, &canceledErr) {
+			canceled++
+		} else if result.Err != nil {
+			t.Errorf("unexpected error on result %d (%v)", result.Index, result.Err)
+		}
+	}
+	if len(seen) != len(accounts) {
+		t.Errorf("expected a result for every account, got %d", len(seen))
+	}
+	if canceled < len(accounts)-2 {
+		t.Errorf("expected the accounts not started to be canceled, got %d canceled", canceled)
+	}
+}
+
+func TestDeleteMany(t *testing.T) {
+	server := accounttest.NewServer()
+	defer server.Close()
+	client := server.Client()
+
+	var deletes []account.DeleteRequest
+	for result := range client.CreateMany(context.Background(), bulkAccounts(5), account.BulkOptions{}) {
+		if result.Err != nil {
+			t.Fatalf("unexpected error (%v)", result.Err)
+		}
+		deletes = append(deletes, account.DeleteRequest{ID: uuid.MustParse(result.Input.Data.ID)})
+	}
+	deletes[1].Version = 7
+
+	for result := range client.DeleteMany(context.Background(), deletes, account.BulkOptions{Ordered: true}) {
+		if result.Index == 1 {
+			if !errors.Is(result.Err, account.ErrConflict) {
+				t.Errorf("expected ErrConflict on wrong version, got (%v)", result.Err)
+			}
+			continue
+		}
+		if result.Err != nil || result.Response.StatusCode != http.StatusNoContent {
+			t.Errorf("unexpected result %d (%+v)", result.Index, result)
+		}
+	}
+	if n := len(server.Accounts()); n != 1 {
+		t.Errorf("expected 1 remaining account, got %d", n)
+	}
+}
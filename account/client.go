@@ -0,0 +1,94 @@
+package account
+
+import (
+	"net/http"
+	"time"
+)
+
+const (
+	// DefaultHost is the Host used by clients created without the WithHost option.
+	DefaultHost = "http://localhost:8080/"
+	// DefaultApiVersion is the api version used by clients created without the WithApiVersion option.
+	DefaultApiVersion = "v1/"
+	// DefaultTimeout is the timeout of the http client used by clients created without the WithHttpClient option.
+	DefaultTimeout = time.Duration(1) * time.Second
+)
+
+// Client is a client of the form3 API for the resource of Organisation Accounts.
+// Every Client owns its own base url, api version and http client, so that
+// several form3 environments can be called from the same process.
+// A Client is safe for concurrent use by multiple goroutines.
+type Client struct {
+	host        string
+	apiVersion  string
+	httpClient  *http.Client
+	retryPolicy RetryPolicy
+	// validateOnCreate makes Create run Account.Validate before calling the API.
+	validateOnCreate bool
+	authenticator    Authenticator
+	middleware       []Middleware
+	logging          *LogConfig
+	tracer           Tracer
+	metrics          Metrics
+	idempotentCreate bool
+	rateLimiter      *RateLimiter
+	circuitBreaker   *CircuitBreaker
+	// strictEnums makes the Client reject unknown enumeration values, see WithStrictEnums.
+	strictEnums bool
+}
+
+// Option configures a Client created through NewClient.
+type Option func(*Client)
+
+// WithHost sets the API http scheme + actual hostname including possible port.
+// It should end with forward slash.
+// Example: "http://localhost:8080/"
+func WithHost(host string) Option {
+	return func(c *Client) {
+		c.host = host
+	}
+}
+
+// WithApiVersion sets the api version.
+// It will be concatenated after the host, therefore
+// it shouldn't have a slash prefix, but should have a slash postfix.
+// Example: "v1/"
+func WithApiVersion(apiVersion string) Option {
+	return func(c *Client) {
+		c.apiVersion = apiVersion
+	}
+}
+
+// WithHttpClient sets the standard http client which will execute the http requests.
+// A nil httpClient is ignored.
+func WithHttpClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		if httpClient != nil {
+			c.httpClient = httpClient
+		}
+	}
+}
+
+// NewClient returns a Client configured with the given options.
+// Options which are not given fall back to DefaultHost, DefaultApiVersion
+// and an http client with a timeout of DefaultTimeout.
+func NewClient(opts ...Option) *Client {
+	c := &Client{
+		host:       DefaultHost,
+		apiVersion: DefaultApiVersion,
+		httpClient: &http.Client{Timeout: DefaultTimeout},
+		metrics:    NopMetrics{},
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	c.httpClient = chain(c.httpClient, c.middleware)
+	return c
+}
+
+// defaultClient returns the Client behind the package level functions.
+// It is built on every call from the Host, ApiVersion and ApiClient variables,
+// so that changes made to them by the caller always take effect.
+var defaultClient = func() *Client {
+	return NewClient(WithHost(Host), WithApiVersion(ApiVersion), WithHttpClient(ApiClient))
+}
@@ -0,0 +1,79 @@
+package account
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestNewClient(t *testing.T) {
+	customHttpClient := &http.Client{Timeout: time.Duration(5) * time.Second}
+	subtests := []struct {
+		name          string
+		opts          []Option
+		expHost       string
+		expApiVersion string
+		expTimeout    time.Duration
+	}{
+		{
+			name:          "Client with default configuration",
+			expHost:       DefaultHost,
+			expApiVersion: DefaultApiVersion,
+			expTimeout:    DefaultTimeout,
+		},
+		{
+			name: "Client with custom configuration",
+			opts: []Option{
+				WithHost("http://accountapi:8080/"),
+				WithApiVersion("v2/"),
+				WithHttpClient(customHttpClient),
+			},
+			expHost:       "http://accountapi:8080/",
+			expApiVersion: "v2/",
+			expTimeout:    customHttpClient.Timeout,
+		},
+		{
+			name:          "Nil http client is ignored",
+			opts:          []Option{WithHttpClient(nil)},
+			expHost:       DefaultHost,
+			expApiVersion: DefaultApiVersion,
+			expTimeout:    DefaultTimeout,
+		},
+	}
+	for _, subtest := range subtests {
+		t.Run(subtest.name, func(t *testing.T) {
+			c := NewClient(subtest.opts...)
+			if c.host != subtest.expHost {
+				t.Errorf("expected host (%s), got (%s)", subtest.expHost, c.host)
+			}
+			if c.apiVersion != subtest.expApiVersion {
+				t.Errorf("expected api version (%s), got (%s)", subtest.expApiVersion, c.apiVersion)
+			}
+			if c.httpClient.Timeout != subtest.expTimeout {
+				t.Errorf("expected timeout (%v), got (%v)", subtest.expTimeout, c.httpClient.Timeout)
+			}
+		})
+	}
+}
+
+func TestDefaultClientFollowsPackageVariables(t *testing.T) {
+	oldHost, oldApiVersion, oldApiClient := Host, ApiVersion, ApiClient
+	defer func() {
+		Host, ApiVersion, ApiClient = oldHost, oldApiVersion, oldApiClient
+	}()
+
+	Host = "http://accountapi:8080/"
+	ApiVersion = "v2/"
+	ApiClient = &http.Client{Timeout: time.Duration(3) * time.Second}
+
+	c := defaultClient()
+	if c.host != Host {
+		t.Errorf("expected host (%s), got (%s)", Host, c.host)
+	}
+	if c.apiVersion != ApiVersion {
+		t.Errorf("expected api version (%s), got (%s)", ApiVersion, c.apiVersion)
+	}
+	if c.httpClient != ApiClient {
+		t.Errorf("expected http client (%p), got (%p)", ApiClient, c.httpClient)
+	}
+}
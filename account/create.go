@@ -1,39 +1,85 @@
 // Package account provides a library that can be used as Client of the Form3 API for the resource of Organisation Accounts.
-// Current implementation offers Create, Fetch and Delete operations only.
+// Current implementation offers Create, Fetch, Update, Delete and List operations,
+// along with EnsureAccount, which creates an Account unless an identical one exists.
+//
+// The package level functions use a default Client configured through the Host,
+// ApiVersion and ApiClient variables. A dedicated Client can be created through NewClient.
+//
+// Every operation has a Context variant (e.g. CreateContext) which propagates
+// the deadline and cancellation of the given context to the http request.
 package account
 
 import (
-	"bytes"
-	"io/ioutil"
-	"strconv"
+	"context"
 
 	"github.com/google/uuid"
 )
 
+// Create enables to create an Account record on the form3 API,
+// using the default Client.
+// See Client.Create for more information.
+func Create(acc Account) (*AccountApiResponse, error) {
+	return defaultClient().Create(acc)
+}
+
+// CreateContext is like Create but carries a context.
+// See Client.CreateContext for more information.
+func CreateContext(ctx context.Context, acc Account) (*AccountApiResponse, error) {
+	return defaultClient().CreateContext(ctx, acc)
+}
+
 // Create enables to create an Account record on the form3 API.
 // It takes as parameter the Account struct the caller wants to create and returns
 // the created Account wrapped inside the AccountApiResponse pointer var,
 // along with the Status and Status Code response details.
 // In case any error occurs while attempting to create the Account,
 // it returns nil, along with the error.
-func Create(acc Account) (*AccountApiResponse, error) {
+func (c *Client) Create(acc Account) (*AccountApiResponse, error) {
+	return c.CreateContext(context.Background(), acc)
+}
+
+// CreateContext is like Create but carries a context.
+// If the context is canceled or its deadline is exceeded before the response
+// is received, it returns nil, along with a *RequestCanceledError.
+// If the Client was created with WithValidation and the Account is invalid,
+// it returns nil, along with a *ValidationError, without calling the form3 API.
+// The idempotency key carried by ctx, if any, is sent along, see WithIdempotentCreate.
+func (c *Client) CreateContext(ctx context.Context, acc Account) (res *AccountApiResponse, err error) {
+	ctx, end := c.startOperation(ctx, accountOperation(OperationCreate, acc))
+	defer func() { end(res.statusCode(), err) }()
+
+	if c.validateOnCreate {
+		if err := acc.validate(c.strictEnums); err != nil {
+			return nil, err
+		}
+	}
+	if c.strictEnums {
+		if err := checkEnums(acc.Data); err != nil {
+			return nil, err
+		}
+	}
 	accountJSON, err := jsonMarshal(acc)
 	if err != nil {
 		return nil, err
 	}
 
-	request, err := newReq(createMethod, uuid.Nil, nil)
+	request, err := newReq(ctx, c, createMethod, uuid.Nil, nil)
 	if err != nil {
 		return nil, err
 	}
-	request.Header.Add("Content-Type", "application/vnd.api+json")
-	request.Header.Add("Content-Length", strconv.Itoa(len([]byte(accountJSON))))
-
-	request.Body = ioutil.NopCloser(bytes.NewReader(accountJSON))
-	response, err := apiCall(request)
+	setJSONBody(request, accountJSON)
+	key := c.createIdempotencyKey(ctx)
+	if key != "" {
+		request.Header.Set(IdempotencyKeyHeader, key)
+	}
+	response, err := apiCall(c, request)
 	if err != nil {
 		return nil, err
 	}
 	defer response.Body.Close()
-	return handleRes(response, createMethod)
+	res, err = handleRes(response, createMethod)
+	if err != nil && key != "" {
+		return c.resolveCreateConflict(ctx, acc, err)
+	}
+	return res, err
 }
@@ -2,6 +2,7 @@ package account
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"io"
@@ -39,19 +40,20 @@ func newStringPointer(s string) *string {
 }
 
 func TestCreate(t *testing.T) {
+	restoreStubs(t)
 
 	subtests := []struct {
 		name             string
-		newReq           func(verb httpMethod, id uuid.UUID, version *int64) (*http.Request, error)
+		newReq           func(ctx context.Context, c *Client, verb httpMethod, id uuid.UUID, version *int64) (*http.Request, error)
 		handleRes        func(response *http.Response, verb httpMethod) (*AccountApiResponse, error)
-		apiCall          func(req *http.Request) (*http.Response, error)
+		apiCall          func(c *Client, req *http.Request) (*http.Response, error)
 		jsonMarshal      func(v any) ([]byte, error)
 		expectedResponse *AccountApiResponse
 		expectedErr      error
 	}{
 		{
 			name: "Successfully created",
-			newReq: func(verb httpMethod, id uuid.UUID, version *int64) (*http.Request, error) {
+			newReq: func(ctx context.Context, c *Client, verb httpMethod, id uuid.UUID, version *int64) (*http.Request, error) {
 				return &http.Request{
 					Header: make(http.Header),
 				}, nil
@@ -59,7 +61,7 @@ func TestCreate(t *testing.T) {
 			handleRes: func(response *http.Response, verb httpMethod) (*AccountApiResponse, error) {
 				return exp_res_created_success, nil
 			},
-			apiCall: func(req *http.Request) (*http.Response, error) {
+			apiCall: func(c *Client, req *http.Request) (*http.Response, error) {
 				return &http.Response{
 					Status:     "Created",
 					StatusCode: http.StatusCreated,
@@ -72,7 +74,7 @@ func TestCreate(t *testing.T) {
 		},
 		{
 			name: "Handle response fails",
-			newReq: func(verb httpMethod, id uuid.UUID, version *int64) (*http.Request, error) {
+			newReq: func(ctx context.Context, c *Client, verb httpMethod, id uuid.UUID, version *int64) (*http.Request, error) {
 				return &http.Request{
 					Header: make(http.Header),
 				}, nil
@@ -83,7 +85,7 @@ func TestCreate(t *testing.T) {
 					Status:     "Bad request",
 				}
 			},
-			apiCall: func(req *http.Request) (*http.Response, error) {
+			apiCall: func(c *Client, req *http.Request) (*http.Response, error) {
 				return &http.Response{
 					Status:     "Bad request",
 					StatusCode: http.StatusBadRequest,
@@ -107,10 +109,10 @@ func TestCreate(t *testing.T) {
 		{
 			name:        "Api Call returns error",
 			jsonMarshal: json.Marshal,
-			apiCall: func(req *http.Request) (*http.Response, error) {
+			apiCall: func(c *Client, req *http.Request) (*http.Response, error) {
 				return nil, errors.New("Failed to do api call")
 			},
-			newReq: func(verb httpMethod, id uuid.UUID, version *int64) (*http.Request, error) {
+			newReq: func(ctx context.Context, c *Client, verb httpMethod, id uuid.UUID, version *int64) (*http.Request, error) {
 				return &http.Request{
 					Header: make(http.Header),
 				}, nil
@@ -120,7 +122,7 @@ func TestCreate(t *testing.T) {
 		{
 			name:        "New Request With Headers returns error",
 			jsonMarshal: json.Marshal,
-			newReq: func(verb httpMethod, id uuid.UUID, version *int64) (*http.Request, error) {
+			newReq: func(ctx context.Context, c *Client, verb httpMethod, id uuid.UUID, version *int64) (*http.Request, error) {
 				return nil, errors.New("Failed to create new request")
 			},
 			expectedErr: errors.New("Failed to create new request"),
@@ -142,3 +144,27 @@ func TestCreate(t *testing.T) {
 		})
 	}
 }
+
+func TestMatchesSubmitted(t *testing.T) {
+	existing := *test_acc.Data
+	attrs := *existing.Attributes
+	status := StatusConfirmed
+	attrs.Status = &status
+	existing.Attributes = &attrs
+	version := int64(0)
+	existing.Version = &version
+
+	if !matchesSubmitted(test_acc.Data, &existing) {
+		t.Error("expected fields set by the API not to prevent a match")
+	}
+	differing := *test_acc.Data
+	differingAttrs := *differing.Attributes
+	differingAttrs.BankID = "400301"
+	differing.Attributes = &differingAttrs
+	if matchesSubmitted(&differing, &existing) {
+		t.Error("expected a differing bank id to prevent a match")
+	}
+	if matchesSubmitted(test_acc.Data, nil) {
+		t.Error("expected no match without an existing account")
+	}
+}
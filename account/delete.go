@@ -1,6 +1,24 @@
 package account
 
-import "github.com/google/uuid"
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+)
+
+// Delete enables to delete an Account record on the form3 API,
+// using the default Client.
+// See Client.Delete for more information.
+func Delete(id uuid.UUID, version int64) (*AccountApiResponse, error) {
+	return defaultClient().Delete(id, version)
+}
+
+// DeleteContext is like Delete but carries a context.
+// See Client.DeleteContext for more information.
+func DeleteContext(ctx context.Context, id uuid.UUID, version int64) (*AccountApiResponse, error) {
+	return defaultClient().DeleteContext(ctx, id, version)
+}
 
 // Delete enables to delete an Account record on the form3 API.
 // It takes as parameter the id of the record (valid uuid) and the version.
@@ -8,15 +26,79 @@ import "github.com/google/uuid"
 // along with the Status and Status Code response details.
 // In case any error occurs while attempting to delete the Account,
 // it returns nil, along with the error.
-func Delete(id uuid.UUID, version int64) (*AccountApiResponse, error) {
-	req, err := newReq(deleteMethod, id, &version)
+func (c *Client) Delete(id uuid.UUID, version int64) (*AccountApiResponse, error) {
+	return c.DeleteContext(context.Background(), id, version)
+}
+
+// DeleteContext is like Delete but carries a context.
+// If the context is canceled or its deadline is exceeded before the response
+// is received, it returns nil, along with a *RequestCanceledError.
+func (c *Client) DeleteContext(ctx context.Context, id uuid.UUID, version int64) (res *AccountApiResponse, err error) {
+	ctx, end := c.startOperation(ctx, Operation{Name: OperationDelete, AccountID: id.String()})
+	defer func() { end(res.statusCode(), err) }()
+
+	req, err := newReq(ctx, c, deleteMethod, id, &version)
 	if err != nil {
 		return nil, err
 	}
-	response, err := apiCall(req)
+	response, err := apiCall(c, req)
 	if err != nil {
 		return nil, err
 	}
 	defer response.Body.Close()
 	return handleRes(response, deleteMethod)
 }
+
+// DeleteLatestMaxAttempts is the number of times DeleteLatest fetches the current version
+// of an Account record and tries to delete it, before giving up on version conflicts.
+const DeleteLatestMaxAttempts = 3
+
+// DeleteLatest enables to delete an Account record on the form3 API whatever its version,
+// using the default Client.
+// See Client.DeleteLatest for more information.
+func DeleteLatest(id uuid.UUID) (*AccountApiResponse, error) {
+	return defaultClient().DeleteLatest(id)
+}
+
+// DeleteLatestContext is like DeleteLatest but carries a context.
+// See Client.DeleteLatestContext for more information.
+func DeleteLatestContext(ctx context.Context, id uuid.UUID) (*AccountApiResponse, error) {
+	return defaultClient().DeleteLatestContext(ctx, id)
+}
+
+// DeleteLatest enables to delete an Account record on the form3 API whatever its version.
+// It takes as parameter the id of the record (valid uuid), fetches its current version
+// and deletes it with that version. In case the record is modified in between, the delete
+// gets a *VersionConflictError and is tried again with the new version,
+// up to DeleteLatestMaxAttempts times.
+// It returns an AccountApiResponse pointer var with nil as ResponseBody
+// along with the Status and Status Code response details of the delete.
+// In case any error occurs, including the last version conflict and a missing record on fetch,
+// it returns nil, along with the error.
+func (c *Client) DeleteLatest(id uuid.UUID) (*AccountApiResponse, error) {
+	return c.DeleteLatestContext(context.Background(), id)
+}
+
+// DeleteLatestContext is like DeleteLatest but carries a context.
+// If the context is canceled or its deadline is exceeded before the response
+// is received, it returns nil, along with a *RequestCanceledError.
+func (c *Client) DeleteLatestContext(ctx context.Context, id uuid.UUID) (*AccountApiResponse, error) {
+	var err error
+	for attempt := 0; attempt < DeleteLatestMaxAttempts; attempt++ {
+		var fetched *AccountApiResponse
+		fetched, err = c.FetchContext(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if fetched.ResponseBody == nil || fetched.ResponseBody.Data == nil || fetched.ResponseBody.Data.Version == nil {
+			return nil, errors.New(delete_latest_missing_version_formatting)
+		}
+		var res *AccountApiResponse
+		res, err = c.DeleteContext(ctx, id, *fetched.ResponseBody.Data.Version)
+		var conflictErr *VersionConflictError
+		if !errors.As(err, &conflictErr) {
+			return res, err
+		}
+	}
+	return nil, err
+}
@@ -0,0 +1,89 @@
+package account_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/edihoxhalli/Form3-exercise/account"
+	"github.com/edihoxhalli/Form3-exercise/account/accounttest"
+	"github.com/google/uuid"
+)
+
+// concurrentUpdates returns a middleware updating the account with the given id
+// before the first n delete requests, as another client would between fetch and delete.
+func concurrentUpdates(t *testing.T, server *accounttest.Server, id uuid.UUID, n int) account.Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return account.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			if req.Method == http.MethodDelete && n > 0 {
+				n--
+				current, err := server.Client().Fetch(id)
+				if err != nil {
+					t.Fatalf("unexpected error (%v)", err)
+				}
+				patch := account.Account{Data: &account.AccountData{
+					ID:         id.String(),
+					Version:    current.ResponseBody.Data.Version,
+					Attributes: &account.AccountAttributes{SecondaryIdentification: uuid.NewString()},
+				}}
+				if _, err := server.Client().Update(patch); err != nil {
+					t.Fatalf("unexpected error (%v)", err)
+				}
+			}
+			return next.RoundTrip(req)
+		})
+	}
+}
+
+func TestDeleteLatest(t *testing.T) {
+	server := accounttest.NewServer()
+	defer server.Close()
+
+	subtests := []struct {
+		name      string
+		conflicts int
+		deleted   bool
+	}{
+		{"no conflict", 0, true},
+		{"conflicts within the attempts", account.DeleteLatestMaxAttempts - 1, true},
+		{"conflicts on every attempt", account.DeleteLatestMaxAttempts, false},
+	}
+	for _, subtest := range subtests {
+		t.Run(subtest.name, func(t *testing.T) {
+			server.Reset()
+			acc := testAccount(uuid.NewString())
+			if _, err := server.Client().Create(acc); err != nil {
+				t.Fatalf("unexpected error (%v)", err)
+			}
+			id := uuid.MustParse(acc.Data.ID)
+			client := server.Client(account.WithMiddleware(concurrentUpdates(t, server, id, subtest.conflicts)))
+
+			deletes := server.Calls(accounttest.EndpointDelete)
+			res, err := client.DeleteLatest(id)
+			if subtest.deleted {
+				if err != nil || res.StatusCode != http.StatusNoContent {
+					t.Errorf("expected the account to be deleted, got (%+v, %v)", res, err)
+				}
+				if n := len(server.Accounts()); n != 0 {
+					t.Errorf("expected no stored account, got %d", n)
+				}
+				return
+			}
+			var conflictErr *account.VersionConflictError
+			if !errors.As(err, &conflictErr) {
+				t.Errorf("expected *VersionConflictError, got (%v)", err)
+			}
+			if calls := server.Calls(accounttest.EndpointDelete) - deletes; calls != account.DeleteLatestMaxAttempts {
+				t.Errorf("expected %d delete attempts, got %d", account.DeleteLatestMaxAttempts, calls)
+			}
+		})
+	}
+}
+
+func TestDeleteLatestNotFound(t *testing.T) {
+	server := accounttest.NewServer()
+	defer server.Close()
+	if _, err := server.Client().DeleteLatest(uuid.New()); !errors.Is(err, account.ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got (%v)", err)
+	}
+}
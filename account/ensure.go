@@ -0,0 +1,159 @@
+package account
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+const account_mismatch_formatting = "ACCOUNT %s ALREADY EXISTS WITH DIFFERENT FIELDS: %s"
+
+// FieldDiff describes a field whose value differs between the desired and the existing Account.
+// Field is the JSON path of the field, e.g. "data.attributes.bank_id".
+// Existing is nil when the existing Account does not have the field.
+type FieldDiff struct {
+	Field    string
+	Desired  interface{}
+	Existing interface{}
+}
+
+func (d FieldDiff) String() string {
+	existing := "absent"
+	if d.Existing != nil {
+		existing = jsonString(d.Existing)
+	}
+	return fmt.Sprintf("%s: desired %s, existing %s", d.Field, jsonString(d.Desired), existing)
+}
+
+// AccountMismatchError is returned by EnsureAccount when an Account with the same ID
+// already exists, but differs from the desired one. It matches ErrConflict through errors.Is.
+type AccountMismatchError struct {
+	// Existing is the existing Account.
+	Existing *Account
+	// Diffs lists every differing field, sorted by Field.
+	Diffs []FieldDiff
+}
+
+func (e *AccountMismatchError) Error() string {
+	diffs := make([]string, 0, len(e.Diffs))
+	for _, d := range e.Diffs {
+		diffs = append(diffs, d.String())
+	}
+	id := ""
+	if e.Existing != nil && e.Existing.Data != nil {
+		id = e.Existing.Data.ID
+	}
+	return fmt.Sprintf(account_mismatch_formatting, id, strings.Join(diffs, "; "))
+}
+
+func (e *AccountMismatchError) Is(tgt error) bool {
+	return tgt == ErrConflict
+}
+
+// EnsureAccount makes sure an Account exists on the form3 API, using the default Client.
+// See Client.EnsureAccount for more information.
+func EnsureAccount(acc Account) (*AccountApiResponse, error) {
+	return defaultClient().EnsureAccount(acc)
+}
+
+// EnsureAccountContext is like EnsureAccount but carries a context.
+// See Client.EnsureAccountContext for more information.
+func EnsureAccountContext(ctx context.Context, acc Account) (*AccountApiResponse, error) {
+	return defaultClient().EnsureAccountContext(ctx, acc)
+}
+
+// EnsureAccount makes sure an Account exists on the form3 API, so that it can safely be called repeatedly.
+// It creates the Account if missing, and returns the created Account with status code 201.
+// If an Account with the same ID already exists and holds every field of acc, it returns the
+// existing Account with the status code 200 of its Fetch.
+// If it exists but differs, it returns nil, along with an *AccountMismatchError listing the differences.
+// In case any other error occurs, it returns nil, along with the error.
+func (c *Client) EnsureAccount(acc Account) (*AccountApiResponse, error) {
+	return c.EnsureAccountContext(context.Background(), acc)
+}
+
+// EnsureAccountContext is like EnsureAccount but carries a context.
+// If the context is canceled or its deadline is exceeded before the response
+// is received, it returns nil, along with a *RequestCanceledError.
+func (c *Client) EnsureAccountContext(ctx context.Context, acc Account) (*AccountApiResponse, error) {
+	res, err := c.CreateContext(ctx, acc)
+	if err == nil || !errors.Is(err, ErrConflict) {
+		return res, err
+	}
+	if acc.Data == nil {
+		return nil, err
+	}
+	id, parseErr := uuid.Parse(acc.Data.ID)
+	if parseErr != nil {
+		return nil, err
+	}
+	existing, fetchErr := c.FetchContext(ctx, id)
+	if fetchErr != nil {
+		return nil, fetchErr
+	}
+	if existing.ResponseBody == nil {
+		return nil, err
+	}
+	diffs, ok := diffSubmitted(acc.Data, existing.ResponseBody.Data)
+	if !ok {
+		return nil, err
+	}
+	if len(diffs) > 0 {
+		return nil, &AccountMismatchError{Existing: existing.ResponseBody, Diffs: diffs}
+	}
+	return existing, nil
+}
+
+// diffSubmitted returns the fields of the submitted AccountData which differ in the existing one.
+// Fields left empty on submission, and fields set by the form3 API such as Version, are not compared.
+// It returns false if the AccountData cannot be compared.
+func diffSubmitted(submitted, existing *AccountData) ([]FieldDiff, bool) {
+	if submitted == nil || existing == nil {
+		return nil, false
+	}
+	var desired, got map[string]interface{}
+	if !decodeAsMap(submitted, &desired) || !decodeAsMap(existing, &got) {
+		return nil, false
+	}
+	delete(desired, "version")
+	var diffs []FieldDiff
+	diffFields("data", desired, got, &diffs)
+	sort.Slice(diffs, func(i, j int) bool { return diffs[i].Field < diffs[j].Field })
+	return diffs, true
+}
+
+func decodeAsMap(v interface{}, m *map[string]interface{}) bool {
+	content, err := json.Marshal(v)
+	return err == nil && json.Unmarshal(content, m) == nil
+}
+
+// diffFields appends to diffs the fields of desired missing or differing in got, recursively for nested objects.
+func diffFields(path string, desired, got map[string]interface{}, diffs *[]FieldDiff) {
+	for key, desiredValue := range desired {
+		field := path + "." + key
+		gotValue := got[key]
+		desiredMap, desiredIsMap := desiredValue.(map[string]interface{})
+		gotMap, gotIsMap := gotValue.(map[string]interface{})
+		if desiredIsMap && gotIsMap {
+			diffFields(field, desiredMap, gotMap, diffs)
+			continue
+		}
+		if !reflect.DeepEqual(desiredValue, gotValue) {
+			*diffs = append(*diffs, FieldDiff{Field: field, Desired: desiredValue, Existing: gotValue})
+		}
+	}
+}
+
+func jsonString(v interface{}) string {
+	content, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(content)
+}
@@ -0,0 +1,62 @@
+package account_test
+
+import (
+	"errors"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/edihoxhalli/Form3-exercise/account"
+	"github.com/edihoxhalli/Form3-exercise/account/accounttest"
+	"github.com/google/uuid"
+)
+
+func TestEnsureAccount(t *testing.T) {
+	server := accounttest.NewServer()
+	defer server.Close()
+	client := server.Client()
+	acc := testAccount(uuid.NewString())
+
+	res, err := client.EnsureAccount(acc)
+	if err != nil {
+		t.Fatalf("unexpected error (%v)", err)
+	}
+	if res.StatusCode != http.StatusCreated {
+		t.Errorf("expected a created account, got status (%d)", res.StatusCode)
+	}
+
+	res, err = client.EnsureAccount(acc)
+	if err != nil {
+		t.Fatalf("unexpected error on identical account (%v)", err)
+	}
+	if res.StatusCode != http.StatusOK || res.ResponseBody.Data.ID != acc.Data.ID {
+		t.Errorf("expected the existing account, got (%+v)", res)
+	}
+
+	differing := testAccount(acc.Data.ID)
+	differing.Data.Attributes.BankID = "400301"
+	differing.Data.Attributes.SecondaryIdentification = "A1B2C3D4"
+	_, err = client.EnsureAccount(differing)
+	if !errors.Is(err, account.ErrConflict) {
+		t.Fatalf("expected ErrConflict, got (%v)", err)
+	}
+	var mismatchErr *account.AccountMismatchError
+	if !errors.As(err, &mismatchErr) {
+		t.Fatalf("expected *AccountMismatchError, got (%T)", err)
+	}
+	expected := []account.FieldDiff{
+		{Field: "data.attributes.bank_id", Desired: "400301", Existing: "400300"},
+		{Field: "data.attributes.secondary_identification", Desired: "A1B2C3D4"},
+	}
+	if !reflect.DeepEqual(mismatchErr.Diffs, expected) {
+		t.Errorf("expected diffs (%v), got (%v)", expected, mismatchErr.Diffs)
+	}
+	if msg := err.Error(); !strings.Contains(msg, `data.attributes.bank_id: desired "400301", existing "400300"`) ||
+		!strings.Contains(msg, `data.attributes.secondary_identification: desired "A1B2C3D4", existing absent`) {
+		t.Errorf("unexpected error message (%s)", msg)
+	}
+	if n := len(server.Accounts()); n != 1 {
+		t.Errorf("expected 1 stored account, got %d", n)
+	}
+}
@@ -0,0 +1,166 @@
+package account
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+const unknown_enum_formatting = "UNKNOWN %s %q, EXPECTED ONE OF: %s"
+
+// Classification is the classification of an account.
+type Classification string
+
+const (
+	ClassificationPersonal Classification = "Personal"
+	ClassificationBusiness Classification = "Business"
+)
+
+// Status is the status of an account, set by the form3 API.
+type Status string
+
+const (
+	StatusPending   Status = "pending"
+	StatusConfirmed Status = "confirmed"
+	StatusClosed    Status = "closed"
+	StatusFailed    Status = "failed"
+)
+
+// BankIDCode identifies the national scheme of the BankID of an account.
+type BankIDCode string
+
+const (
+	BankIDCodeAUBSB BankIDCode = "AUBSB"
+	BankIDCodeBE    BankIDCode = "BE"
+	BankIDCodeCACPA BankIDCode = "CACPA"
+	BankIDCodeCHBCC BankIDCode = "CHBCC"
+	BankIDCodeDEBLZ BankIDCode = "DEBLZ"
+	BankIDCodeESNCC BankIDCode = "ESNCC"
+	BankIDCodeFR    BankIDCode = "FR"
+	BankIDCodeGBDSC BankIDCode = "GBDSC"
+	BankIDCodeGRBIC BankIDCode = "GRBIC"
+	BankIDCodeHKNCC BankIDCode = "HKNCC"
+	BankIDCodeITNCC BankIDCode = "ITNCC"
+	BankIDCodeLULUX BankIDCode = "LULUX"
+	BankIDCodePLKNR BankIDCode = "PLKNR"
+	BankIDCodePTNCC BankIDCode = "PTNCC"
+	BankIDCodeUSABA BankIDCode = "USABA"
+)
+
+var (
+	classifications = []Classification{ClassificationPersonal, ClassificationBusiness}
+	statuses        = []Status{StatusPending, StatusConfirmed, StatusClosed, StatusFailed}
+	bankIDCodes     = []BankIDCode{
+		BankIDCodeAUBSB, BankIDCodeBE, BankIDCodeCACPA, BankIDCodeCHBCC, BankIDCodeDEBLZ,
+		BankIDCodeESNCC, BankIDCodeFR, BankIDCodeGBDSC, BankIDCodeGRBIC, BankIDCodeHKNCC,
+		BankIDCodeITNCC, BankIDCodeLULUX, BankIDCodePLKNR, BankIDCodePTNCC, BankIDCodeUSABA,
+	}
+)
+
+// WithStrictEnums makes Create and Update reject Accounts holding Classification, Status or
+// BankIDCode values unknown to this package with an *UnknownEnumError, e.g. to catch typos
+// before calling the form3 API. By default unknown values are accepted.
+// Accounts received from the form3 API are never rejected, so that values introduced by
+// the form3 API after this version of the package are kept; see DecodeAccount to decode
+// Accounts strictly outside of a Client.
+func WithStrictEnums() Option {
+	return func(c *Client) {
+		c.strictEnums = true
+	}
+}
+
+// UnknownEnumError is returned by Clients created with WithStrictEnums, and by DecodeAccount
+// in strict mode, for an Account holding an unknown enumeration value.
+// It matches ErrValidation through errors.Is.
+type UnknownEnumError struct {
+	// Enum is the name of the enumeration, e.g. "CLASSIFICATION".
+	Enum  string
+	Value string
+	// Known lists the values known to this package.
+	Known []string
+}
+
+func (e *UnknownEnumError) Error() string {
+	return fmt.Sprintf(unknown_enum_formatting, e.Enum, e.Value, strings.Join(e.Known, ", "))
+}
+
+func (e *UnknownEnumError) Is(tgt error) bool {
+	return tgt == ErrValidation
+}
+
+// Valid reports whether c is known to this package.
+func (c Classification) Valid() bool {
+	return contains(classifications, c)
+}
+
+// Valid reports whether s is known to this package.
+func (s Status) Valid() bool {
+	return contains(statuses, s)
+}
+
+// Valid reports whether code is known to this package.
+func (code BankIDCode) Valid() bool {
+	return contains(bankIDCodes, code)
+}
+
+func contains[T ~string](known []T, value T) bool {
+	for _, k := range known {
+		if k == value {
+			return true
+		}
+	}
+	return false
+}
+
+// DecodeAccount decodes the JSON encoding of an Account, e.g. received through a webhook or stored.
+// Unknown Classification, Status and BankIDCode values are kept, unless strict is set,
+// in which case an *UnknownEnumError is returned for the first one.
+func DecodeAccount(data []byte, strict bool) (*Account, error) {
+	var acc Account
+	if err := json.Unmarshal(data, &acc); err != nil {
+		return nil, err
+	}
+	if strict {
+		if err := checkEnums(acc.Data); err != nil {
+			return nil, err
+		}
+	}
+	return &acc, nil
+}
+
+// checkEnums returns an *UnknownEnumError for the first unknown enumeration value of data, if any.
+func checkEnums(data *AccountData) error {
+	if data == nil || data.Attributes == nil {
+		return nil
+	}
+	attrs := data.Attributes
+	if attrs.AccountClassification != nil {
+		if err := checkEnum("CLASSIFICATION", *attrs.AccountClassification, classifications); err != nil {
+			return err
+		}
+	}
+	if err := checkEnum("BANK ID CODE", attrs.BankIDCode, bankIDCodes); err != nil {
+		return err
+	}
+	if attrs.Status != nil {
+		return checkEnum("STATUS", *attrs.Status, statuses)
+	}
+	return nil
+}
+
+// checkEnum returns an *UnknownEnumError if value is unknown.
+// The empty value, meaning the field is not set, is always accepted.
+func checkEnum[T ~string](enum string, value T, known []T) error {
+	if value == "" || contains(known, value) {
+		return nil
+	}
+	return &UnknownEnumError{Enum: enum, Value: string(value), Known: enumStrings(known)}
+}
+
+func enumStrings[T ~string](values []T) []string {
+	strs := make([]string, len(values))
+	for i, v := range values {
+		strs[i] = string(v)
+	}
+	return strs
+}
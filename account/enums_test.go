@@ -0,0 +1,114 @@
+package account
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestEnumsLenient(t *testing.T) {
+	var attrs AccountAttributes
+	body := `{"account_classification":"Corporate","bank_id_code":"SESBA","status":"suspended"}`
+	if err := json.Unmarshal([]byte(body), &attrs); err != nil {
+		t.Fatalf("unexpected error (%v)", err)
+	}
+	if *attrs.AccountClassification != "Corporate" || attrs.BankIDCode != "SESBA" || *attrs.Status != "suspended" {
+		t.Errorf("expected unknown values to be kept, got (%+v)", attrs)
+	}
+	encoded, err := json.Marshal(attrs)
+	if err != nil || string(encoded) != body {
+		t.Errorf("expected unknown values to round trip, got (%s, %v)", encoded, err)
+	}
+}
+
+func TestStrictEnums(t *testing.T) {
+	restoreStubs(t)
+	body := `{"data":{"attributes":{"country":"GB","account_classification":"Corporate","status":"suspended"},` +
+		`"id":"ad27e265-9605-4b4b-a0e5-3003ea9cc4dc","type":"accounts"}}`
+	apiCall = func(c *Client, req *http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusOK, Status: "200 OK", Body: io.NopCloser(strings.NewReader(body))}, nil
+	}
+
+	id := uuid.MustParse(test_acc.Data.ID)
+	for _, c := range []*Client{NewClient(), NewClient(WithStrictEnums())} {
+		res, err := c.Fetch(id)
+		if err != nil || *res.ResponseBody.Data.Attributes.Status != "suspended" {
+			t.Errorf("expected unknown values of responses to be kept, got (%+v) and error (%v)", res, err)
+		}
+	}
+	apiCall = func(c *Client, req *http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusCreated, Status: "201 Created", Body: io.NopCloser(strings.NewReader(body))}, nil
+	}
+	res, err := NewClient(WithStrictEnums()).Create(test_acc)
+	if err != nil || res == nil || res.StatusCode != http.StatusCreated {
+		t.Errorf("expected a successful create to be returned whatever its response values, got (%+v) and error (%v)", res, err)
+	}
+
+	apiCall = func(c *Client, req *http.Request) (*http.Response, error) {
+		t.Fatalf("expected no api call for an unknown enumeration value")
+		return nil, nil
+	}
+	data := *test_acc.Data
+	attrs := *data.Attributes
+	attrs.BankIDCode = "GBDCS"
+	data.Attributes, data.Version = &attrs, int64ToPointer(0)
+	_, err = NewClient(WithStrictEnums()).Create(Account{Data: &data})
+	var enumErr *UnknownEnumError
+	if !errors.As(err, &enumErr) || enumErr.Enum != "BANK ID CODE" || enumErr.Value != "GBDCS" {
+		t.Errorf("expected *UnknownEnumError on create, got (%v)", err)
+	}
+	_, err = NewClient(WithStrictEnums()).Update(Account{Data: &data})
+	if !errors.As(err, &enumErr) || enumErr.Enum != "BANK ID CODE" {
+		t.Errorf("expected *UnknownEnumError on update, got (%v)", err)
+	}
+}
+
+func TestDecodeAccount(t *testing.T) {
+	body := []byte(`{"data":{"attributes":{"country":"GB","status":"suspended"},"type":"accounts"}}`)
+	acc, err := DecodeAccount(body, false)
+	if err != nil || *acc.Data.Attributes.Status != "suspended" {
+		t.Errorf("expected unknown values to be kept, got (%+v) and error (%v)", acc, err)
+	}
+	acc, err = DecodeAccount(body, true)
+	var enumErr *UnknownEnumError
+	if acc != nil || !errors.As(err, &enumErr) || enumErr.Enum != "STATUS" || !errors.Is(err, ErrValidation) {
+		t.Errorf("expected *UnknownEnumError in strict mode, got (%+v) and error (%v)", acc, err)
+	}
+	if _, err := DecodeAccount([]byte(`{"data":`), true); err == nil {
+		t.Error("expected an error for invalid JSON")
+	}
+}
+
+func TestValidateEnums(t *testing.T) {
+	restoreStubs(t)
+	apiCall = func(c *Client, req *http.Request) (*http.Response, error) {
+		t.Fatalf("expected no api call for an invalid account")
+		return nil, nil
+	}
+	data := *test_acc.Data
+	attrs := *data.Attributes
+	classification, status := Classification("personal"), Status("active")
+	attrs.AccountClassification, attrs.Status = &classification, &status
+	data.Attributes = &attrs
+
+	if err := data.Validate(); err != nil {
+		t.Errorf("expected unknown values to be accepted by Validate, got (%v)", err)
+	}
+	_, err := NewClient(WithValidation(), WithStrictEnums()).Create(Account{Data: &data})
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("expected a *ValidationError, got (%v)", err)
+	}
+	fields := map[string]bool{}
+	for _, v := range validationErr.Violations {
+		fields[v.Field] = true
+	}
+	if !fields["data.attributes.account_classification"] || !fields["data.attributes.status"] || len(fields) != 2 {
+		t.Errorf("unexpected violations (%v)", validationErr.Violations)
+	}
+}
@@ -0,0 +1,130 @@
+package account
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+const (
+	api_error_formatting        = "ACCOUNT API ERROR\nSTATUS CODE : %d\nSTATUS : %s\nRESPONSE BODY : %s\nMESSAGE : %s"
+	request_canceled_formatting = "ACCOUNT API REQUEST CANCELED: %s"
+	version_conflict_formatting = "ACCOUNT VERSION CONFLICT\n%s"
+)
+
+// Sentinel errors matching the class of an *ApiError through errors.Is, e.g.
+//
+//	if errors.Is(err, account.ErrNotFound) {
+//		...
+//	}
+var (
+	// ErrNotFound matches responses with status 404 Not Found.
+	ErrNotFound = errors.New("ACCOUNT API NOT FOUND")
+	// ErrConflict matches responses with status 409 Conflict,
+	// e.g. duplicate ids on create or version mismatches on update and delete.
+	ErrConflict = errors.New("ACCOUNT API CONFLICT")
+	// ErrValidation matches responses with status 400 Bad Request or 422 Unprocessable Entity.
+	ErrValidation = errors.New("ACCOUNT API VALIDATION FAILED")
+	// ErrUnauthorized matches responses with status 401 Unauthorized or 403 Forbidden.
+	ErrUnauthorized = errors.New("ACCOUNT API UNAUTHORIZED")
+	// ErrRateLimited matches responses with status 429 Too Many Requests.
+	ErrRateLimited = errors.New("ACCOUNT API RATE LIMITED")
+	// ErrServer matches responses with a 5xx status.
+	ErrServer = errors.New("ACCOUNT API SERVER ERROR")
+	// ErrCircuitOpen is returned without calling the form3 API while the CircuitBreaker
+	// of the Client is open, see WithCircuitBreaker.
+	ErrCircuitOpen = errors.New("ACCOUNT API CIRCUIT OPEN")
+)
+
+// ApiError is a custom error being returned in case of an error response from form3 API.
+// ResponseBody keeps the raw body for diagnostics, while ErrorMessage and ErrorCode
+// hold the decoded form3 error payload, when the body contains one.
+type ApiError struct {
+	StatusCode   int
+	Status       string
+	ResponseBody string
+	Message      string
+	ErrorMessage string
+	ErrorCode    string
+}
+
+// apiErrorBody represents the error payload returned by the form3 API.
+type apiErrorBody struct {
+	ErrorMessage string `json:"error_message"`
+	ErrorCode    string `json:"error_code"`
+}
+
+func newApiError(statusCode int, status string, responseBody []byte, message string) *ApiError {
+	apiErr := &ApiError{
+		StatusCode:   statusCode,
+		Status:       status,
+		ResponseBody: string(responseBody),
+		Message:      message,
+	}
+	var payload apiErrorBody
+	if json.Unmarshal(responseBody, &payload) == nil {
+		apiErr.ErrorMessage = payload.ErrorMessage
+		apiErr.ErrorCode = payload.ErrorCode
+	}
+	return apiErr
+}
+
+func (e *ApiError) Error() string {
+	return fmt.Sprintf(api_error_formatting,
+		e.StatusCode, e.Status, e.ResponseBody, e.Message)
+}
+
+// Is reports whether the ApiError belongs to the class of the given sentinel error
+// (ErrNotFound, ErrConflict, ...), or whether it holds the same details as the given *ApiError.
+func (e *ApiError) Is(tgt error) bool {
+	switch tgt {
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrConflict:
+		return e.StatusCode == http.StatusConflict
+	case ErrValidation:
+		return e.StatusCode == http.StatusBadRequest || e.StatusCode == http.StatusUnprocessableEntity
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized || e.StatusCode == http.StatusForbidden
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	case ErrServer:
+		return e.StatusCode >= http.StatusInternalServerError
+	}
+	t, ok := tgt.(*ApiError)
+	return ok && t != nil && e.StatusCode == t.StatusCode && e.Status == t.Status &&
+		e.ResponseBody == t.ResponseBody && e.Message == t.Message
+}
+
+// RequestCanceledError is returned when the context of an operation is canceled
+// or its deadline is exceeded before the form3 API responds.
+// It wraps the context error, so that errors.Is(err, context.Canceled)
+// and errors.Is(err, context.DeadlineExceeded) can be used on it.
+type RequestCanceledError struct {
+	Err error
+}
+
+func (e *RequestCanceledError) Error() string {
+	return fmt.Sprintf(request_canceled_formatting, e.Err)
+}
+
+func (e *RequestCanceledError) Unwrap() error {
+	return e.Err
+}
+
+// VersionConflictError is returned when an update or a delete is rejected with
+// status 409 Conflict because the given version is not the current version of the Account.
+// Callers can fetch the Account again to get its current version and retry.
+// It matches ErrConflict through errors.Is.
+type VersionConflictError struct {
+	*ApiError
+}
+
+func (e *VersionConflictError) Error() string {
+	return fmt.Sprintf(version_conflict_formatting, e.ApiError.Error())
+}
+
+func (e *VersionConflictError) Unwrap() error {
+	return e.ApiError
+}
@@ -0,0 +1,98 @@
+package account
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestNewApiError(t *testing.T) {
+	subtests := []struct {
+		name            string
+		responseBody    string
+		expErrorMessage string
+		expErrorCode    string
+	}{
+		{
+			name:            "Form3 error payload decoded",
+			responseBody:    `{"error_message":"validation failure","error_code":"f2a4b2e2-3b8e-4a51-a8e8-7d2d6e3ac7b6"}`,
+			expErrorMessage: "validation failure",
+			expErrorCode:    "f2a4b2e2-3b8e-4a51-a8e8-7d2d6e3ac7b6",
+		},
+		{
+			name:         "Non JSON body kept raw only",
+			responseBody: "upstream connect error",
+		},
+		{
+			name:         "Empty body",
+			responseBody: "",
+		},
+	}
+	for _, subtest := range subtests {
+		t.Run(subtest.name, func(t *testing.T) {
+			result := newApiError(http.StatusBadRequest, "400 Bad Request", []byte(subtest.responseBody), "message")
+			if result.ResponseBody != subtest.responseBody {
+				t.Errorf("expected response body (%s), got (%s)", subtest.responseBody, result.ResponseBody)
+			}
+			if result.ErrorMessage != subtest.expErrorMessage {
+				t.Errorf("expected error message (%s), got (%s)", subtest.expErrorMessage, result.ErrorMessage)
+			}
+			if result.ErrorCode != subtest.expErrorCode {
+				t.Errorf("expected error code (%s), got (%s)", subtest.expErrorCode, result.ErrorCode)
+			}
+		})
+	}
+}
+
+func TestApiErrorIs(t *testing.T) {
+	sentinels := []error{ErrNotFound, ErrConflict, ErrValidation, ErrUnauthorized, ErrRateLimited, ErrServer}
+	subtests := []struct {
+		name       string
+		err        error
+		expMatches []error
+	}{
+		{"Not found", &ApiError{StatusCode: http.StatusNotFound}, []error{ErrNotFound}},
+		{"Conflict", &ApiError{StatusCode: http.StatusConflict}, []error{ErrConflict}},
+		{"Version conflict", &VersionConflictError{&ApiError{StatusCode: http.StatusConflict}}, []error{ErrConflict}},
+		{"Bad request", &ApiError{StatusCode: http.StatusBadRequest}, []error{ErrValidation}},
+		{"Unprocessable entity", &ApiError{StatusCode: http.StatusUnprocessableEntity}, []error{ErrValidation}},
+		{"Unauthorized", &ApiError{StatusCode: http.StatusUnauthorized}, []error{ErrUnauthorized}},
+		{"Forbidden", &ApiError{StatusCode: http.StatusForbidden}, []error{ErrUnauthorized}},
+		{"Too many requests", &ApiError{StatusCode: http.StatusTooManyRequests}, []error{ErrRateLimited}},
+		{"Service unavailable", &ApiError{StatusCode: http.StatusServiceUnavailable}, []error{ErrServer}},
+		{"Wrapped", fmt.Errorf("fetching: %w", &ApiError{StatusCode: http.StatusNotFound}), []error{ErrNotFound}},
+		{"Unexpected success status", &ApiError{StatusCode: http.StatusOK}, nil},
+		{"Canceled", &RequestCanceledError{context.Canceled}, nil},
+	}
+	for _, subtest := range subtests {
+		t.Run(subtest.name, func(t *testing.T) {
+			for _, sentinel := range sentinels {
+				expected := false
+				for _, match := range subtest.expMatches {
+					expected = expected || match == sentinel
+				}
+				if errors.Is(subtest.err, sentinel) != expected {
+					t.Errorf("expected errors.Is(%v) to be %v", sentinel, expected)
+				}
+			}
+		})
+	}
+}
+
+func TestApiErrorIsApiError(t *testing.T) {
+	err := newApiError(http.StatusNotFound, "404 Not Found", []byte(`{"error_message":"not found"}`), "message")
+	same := &ApiError{StatusCode: http.StatusNotFound, Status: "404 Not Found", ResponseBody: `{"error_message":"not found"}`, Message: "message"}
+	other := &ApiError{StatusCode: http.StatusNotFound, Status: "404 Not Found", Message: "other"}
+	if !errors.Is(err, same) {
+		t.Errorf("expected (%v) to match (%v)", err, same)
+	}
+	if errors.Is(err, other) {
+		t.Errorf("expected (%v) not to match (%v)", err, other)
+	}
+	var apiErr *ApiError
+	if !errors.As(fmt.Errorf("wrapped: %w", err), &apiErr) || apiErr.ErrorMessage != "not found" {
+		t.Errorf("expected errors.As to extract the ApiError, got (%+v)", apiErr)
+	}
+}
@@ -1,21 +1,46 @@
 package account
 
 import (
+	"context"
+
 	"github.com/google/uuid"
 )
 
+// Fetch enables to get/retrieve an Account record on the form3 API,
+// using the default Client.
+// See Client.Fetch for more information.
+func Fetch(id uuid.UUID) (*AccountApiResponse, error) {
+	return defaultClient().Fetch(id)
+}
+
+// FetchContext is like Fetch but carries a context.
+// See Client.FetchContext for more information.
+func FetchContext(ctx context.Context, id uuid.UUID) (*AccountApiResponse, error) {
+	return defaultClient().FetchContext(ctx, id)
+}
+
 // Fetch enables to get/retrieve an Account record on the form3 API.
 // It takes as parameter the id of the record (valid uuid).
 // It returns an AccountApiResponse pointer var with the retrieved Account as ResponseBody
 // along with the Status and Status Code response details.
 // In case any error occurs while attempting to fetch the Account,
 // it returns nil, along with the error.
-func Fetch(id uuid.UUID) (*AccountApiResponse, error) {
-	req, err := newReq(fetchMethod, id, nil)
+func (c *Client) Fetch(id uuid.UUID) (*AccountApiResponse, error) {
+	return c.FetchContext(context.Background(), id)
+}
+
+// FetchContext is like Fetch but carries a context.
+// If the context is canceled or its deadline is exceeded before the response
+// is received, it returns nil, along with a *RequestCanceledError.
+func (c *Client) FetchContext(ctx context.Context, id uuid.UUID) (res *AccountApiResponse, err error) {
+	ctx, end := c.startOperation(ctx, Operation{Name: OperationFetch, AccountID: id.String()})
+	defer func() { end(res.statusCode(), err) }()
+
+	req, err := newReq(ctx, c, fetchMethod, id, nil)
 	if err != nil {
 		return nil, err
 	}
-	response, err := apiCall(req)
+	response, err := apiCall(c, req)
 	if err != nil {
 		return nil, err
 	}
@@ -2,6 +2,7 @@ package account
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"io"
 	"net/http"
@@ -20,17 +21,18 @@ var (
 )
 
 func TestFetch(t *testing.T) {
+	restoreStubs(t)
 	subtests := []struct {
 		name             string
-		newReq           func(verb httpMethod, id uuid.UUID, version *int64) (*http.Request, error)
+		newReq           func(ctx context.Context, c *Client, verb httpMethod, id uuid.UUID, version *int64) (*http.Request, error)
 		handleRes        func(response *http.Response, verb httpMethod) (*AccountApiResponse, error)
-		apiCall          func(req *http.Request) (*http.Response, error)
+		apiCall          func(c *Client, req *http.Request) (*http.Response, error)
 		expectedResponse *AccountApiResponse
 		expectedErr      error
 	}{
 		{
 			name: "Successfully fetched",
-			newReq: func(verb httpMethod, id uuid.UUID, version *int64) (*http.Request, error) {
+			newReq: func(ctx context.Context, c *Client, verb httpMethod, id uuid.UUID, version *int64) (*http.Request, error) {
 				return &http.Request{
 					Header: make(http.Header),
 				}, nil
@@ -38,7 +40,7 @@ func TestFetch(t *testing.T) {
 			handleRes: func(response *http.Response, verb httpMethod) (*AccountApiResponse, error) {
 				return exp_res_fetch_success, nil
 			},
-			apiCall: func(req *http.Request) (*http.Response, error) {
+			apiCall: func(c *Client, req *http.Request) (*http.Response, error) {
 				return &http.Response{
 					Status:     "No content",
 					StatusCode: http.StatusNoContent,
@@ -50,7 +52,7 @@ func TestFetch(t *testing.T) {
 		},
 		{
 			name: "Handle response fails",
-			newReq: func(verb httpMethod, id uuid.UUID, version *int64) (*http.Request, error) {
+			newReq: func(ctx context.Context, c *Client, verb httpMethod, id uuid.UUID, version *int64) (*http.Request, error) {
 				return &http.Request{
 					Header: make(http.Header),
 				}, nil
@@ -61,7 +63,7 @@ func TestFetch(t *testing.T) {
 					Status:     "Not found",
 				}
 			},
-			apiCall: func(req *http.Request) (*http.Response, error) {
+			apiCall: func(c *Client, req *http.Request) (*http.Response, error) {
 				return &http.Response{
 					Status:     "Not found",
 					StatusCode: http.StatusNotFound,
@@ -76,10 +78,10 @@ func TestFetch(t *testing.T) {
 		},
 		{
 			name: "Api Call returns error",
-			apiCall: func(req *http.Request) (*http.Response, error) {
+			apiCall: func(c *Client, req *http.Request) (*http.Response, error) {
 				return nil, errors.New("Failed to do api call")
 			},
-			newReq: func(verb httpMethod, id uuid.UUID, version *int64) (*http.Request, error) {
+			newReq: func(ctx context.Context, c *Client, verb httpMethod, id uuid.UUID, version *int64) (*http.Request, error) {
 				return &http.Request{
 					Header: make(http.Header),
 				}, nil
@@ -88,7 +90,7 @@ func TestFetch(t *testing.T) {
 		},
 		{
 			name: "New Request With Headers returns error",
-			newReq: func(verb httpMethod, id uuid.UUID, version *int64) (*http.Request, error) {
+			newReq: func(ctx context.Context, c *Client, verb httpMethod, id uuid.UUID, version *int64) (*http.Request, error) {
 				return nil, errors.New("Failed to create new request")
 			},
 			expectedErr: errors.New("Failed to create new request"),
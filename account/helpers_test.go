@@ -0,0 +1,23 @@
+package account_test
+
+import "github.com/edihoxhalli/Form3-exercise/account"
+
+// testAccount returns a valid GB Account with the given id.
+func testAccount(id string) account.Account {
+	country := "GB"
+	return account.Account{
+		Data: &account.AccountData{
+			Attributes: &account.AccountAttributes{
+				Country:      &country,
+				BaseCurrency: "GBP",
+				BankID:       "400300",
+				BankIDCode:   "GBDSC",
+				Bic:          "NWBKGB22",
+				Name:         []string{"Samantha Holder"},
+			},
+			Type:           "accounts",
+			ID:             id,
+			OrganisationID: "eb0bd6f5-c3f5-44b2-b677-acd23cdde73c",
+		},
+	}
+}
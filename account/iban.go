@@ -0,0 +1,62 @@
+package account
+
+import (
+	"errors"
+
+	"github.com/edihoxhalli/Form3-exercise/account/iban"
+)
+
+const fill_iban_missing_fields_formatting = "FILLING THE IBAN REQUIRES COUNTRY, BANK ID AND ACCOUNT NUMBER (AND BIC FOR GB, IE AND NL)"
+
+// GenerateIban builds a valid IBAN from the Country, BankID, Bic and AccountNumber of attrs.
+// For GB and IE, the bank code is taken from the first four characters of Bic and the
+// sort code from BankID, while for NL the bank code is taken from Bic only.
+// Countries whose BBAN holds national check digits are not supported (see iban.Generate).
+func GenerateIban(attrs AccountAttributes) (string, error) {
+	if attrs.Country == nil || attrs.AccountNumber == "" {
+		return "", errors.New(fill_iban_missing_fields_formatting)
+	}
+	bankCode, branchCode, err := ibanBankCodes(attrs)
+	if err != nil {
+		return "", err
+	}
+	return iban.Generate(*attrs.Country, bankCode, branchCode, attrs.AccountNumber)
+}
+
+// ibanBankCodes returns the bank and branch codes that the IBAN of attrs holds,
+// as derived from its Country, BankID and Bic.
+func ibanBankCodes(attrs AccountAttributes) (bankCode, branchCode string, err error) {
+	switch *attrs.Country {
+	case "GB", "IE", "NL":
+		if len(attrs.Bic) < 4 {
+			return "", "", errors.New(fill_iban_missing_fields_formatting)
+		}
+		bankCode = attrs.Bic[:4]
+		if *attrs.Country != "NL" {
+			branchCode = attrs.BankID
+		}
+	case "GR":
+		if len(attrs.BankID) != 7 {
+			return "", "", iban.ErrInvalidFormat
+		}
+		bankCode, branchCode = attrs.BankID[:3], attrs.BankID[3:]
+	default:
+		bankCode = attrs.BankID
+	}
+	if bankCode == "" {
+		return "", "", errors.New(fill_iban_missing_fields_formatting)
+	}
+	return bankCode, branchCode, nil
+}
+
+// FillIban sets attrs.Iban to the IBAN generated by GenerateIban,
+// so that it is always consistent with the other bank details of the Account.
+// In case of error, attrs is left unchanged.
+func (attrs *AccountAttributes) FillIban() error {
+	generated, err := GenerateIban(*attrs)
+	if err != nil {
+		return err
+	}
+	attrs.Iban = generated
+	return nil
+}
@@ -0,0 +1,205 @@
+// Package iban provides validation, parsing and generation of International Bank Account Numbers
+// (ISO 13616). The BBAN structure is known for the countries supported by the form3 API,
+// while IBANs of other countries are only checked for their format and checksum.
+package iban
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+var (
+	// ErrInvalidFormat is returned for IBANs which are not made of a country code,
+	// two check digits and an alphanumeric BBAN.
+	ErrInvalidFormat = errors.New("IBAN HAS AN INVALID FORMAT")
+	// ErrInvalidLength is returned for IBANs whose length does not match their country.
+	ErrInvalidLength = errors.New("IBAN HAS AN INVALID LENGTH FOR ITS COUNTRY")
+	// ErrInvalidChecksum is returned for IBANs whose check digits are wrong.
+	ErrInvalidChecksum = errors.New("IBAN HAS AN INVALID CHECKSUM")
+	// ErrUnsupportedCountry is returned by Generate for countries without a known BBAN structure,
+	// or whose BBAN holds national check digits.
+	ErrUnsupportedCountry = errors.New("IBAN COUNTRY IS NOT SUPPORTED")
+)
+
+type segmentKind int
+
+const (
+	bank segmentKind = iota
+	branch
+	account
+	nationalCheck
+)
+
+type segment struct {
+	kind   segmentKind
+	length int
+}
+
+// maxLength is the maximum length of an IBAN, whatever its country.
+const maxLength = 34
+
+// bbanLayouts holds the ordered BBAN segments of every supported country.
+var bbanLayouts = map[string][]segment{
+	"AT": {{bank, 5}, {account, 11}},
+	"BE": {{bank, 3}, {account, 7}, {nationalCheck, 2}},
+	"CH": {{bank, 5}, {account, 12}},
+	"DE": {{bank, 8}, {account, 10}},
+	"ES": {{bank, 4}, {branch, 4}, {nationalCheck, 2}, {account, 10}},
+	"FR": {{bank, 5}, {branch, 5}, {account, 11}, {nationalCheck, 2}},
+	"GB": {{bank, 4}, {branch, 6}, {account, 8}},
+	"GR": {{bank, 3}, {branch, 4}, {account, 16}},
+	"IE": {{bank, 4}, {branch, 6}, {account, 8}},
+	"IT": {{nationalCheck, 1}, {bank, 5}, {branch, 5}, {account, 12}},
+	"LU": {{bank, 3}, {account, 13}},
+	"NL": {{bank, 4}, {account, 10}},
+	"PL": {{bank, 3}, {branch, 4}, {nationalCheck, 1}, {account, 16}},
+	"PT": {{bank, 4}, {branch, 4}, {account, 11}, {nationalCheck, 2}},
+}
+
+// IBAN holds the parts of a parsed IBAN.
+type IBAN struct {
+	CountryCode   string
+	CheckDigits   string
+	BankCode      string
+	BranchCode    string
+	AccountNumber string
+	// NationalCheckDigits holds the national check digits of the BBAN, for the countries having them.
+	NationalCheckDigits string
+	// BBAN is the Basic Bank Account Number, i.e. the IBAN without country code and check digits.
+	BBAN string
+}
+
+// String returns the IBAN in its electronic format.
+func (i IBAN) String() string {
+	return i.CountryCode + i.CheckDigits + i.BBAN
+}
+
+// Validate checks the format, the country specific length and the mod-97 checksum of iban,
+// given in its electronic format (no spaces). The length is only checked for the countries
+// with a known BBAN structure.
+func Validate(iban string) error {
+	_, err := Parse(iban)
+	return err
+}
+
+// Parse validates iban and splits it into its parts.
+// For countries without a known BBAN structure, only CountryCode, CheckDigits and BBAN are set.
+func Parse(iban string) (*IBAN, error) {
+	if len(iban) < 5 || len(iban) > maxLength || !isUpperAlpha(iban[:2]) || !isDigits(iban[2:4]) ||
+		!isUpperAlphanumeric(iban[4:]) {
+		return nil, ErrInvalidFormat
+	}
+	layout, ok := bbanLayouts[iban[:2]]
+	if ok && len(iban) != 4+layoutLength(layout) {
+		return nil, ErrInvalidLength
+	}
+	if mod97(iban[4:]+iban[:4]) != 1 {
+		return nil, ErrInvalidChecksum
+	}
+	parsed := &IBAN{CountryCode: iban[:2], CheckDigits: iban[2:4], BBAN: iban[4:]}
+	pos := 0
+	for _, s := range layout {
+		part := parsed.BBAN[pos : pos+s.length]
+		switch s.kind {
+		case bank:
+			parsed.BankCode = part
+		case branch:
+			parsed.BranchCode = part
+		case account:
+			parsed.AccountNumber = part
+		case nationalCheck:
+			parsed.NationalCheckDigits = part
+		}
+		pos += s.length
+	}
+	return parsed, nil
+}
+
+// Generate builds a valid IBAN from its parts, computing the check digits.
+// bankCode and branchCode must have the exact length required by the country,
+// while accountNumber is left padded with zeros.
+// Countries whose BBAN holds national check digits are not supported.
+func Generate(countryCode, bankCode, branchCode, accountNumber string) (string, error) {
+	layout, ok := bbanLayouts[countryCode]
+	if !ok {
+		return "", fmt.Errorf("%w: %s", ErrUnsupportedCountry, countryCode)
+	}
+	for _, s := range layout {
+		if s.kind == nationalCheck {
+			return "", fmt.Errorf("%w: %s requires national check digits", ErrUnsupportedCountry, countryCode)
+		}
+	}
+	var bban strings.Builder
+	for _, s := range layout {
+		var part string
+		switch s.kind {
+		case bank:
+			part = bankCode
+		case branch:
+			part = branchCode
+		case account:
+			if len(accountNumber) < s.length {
+				part = strings.Repeat("0", s.length-len(accountNumber)) + accountNumber
+			} else {
+				part = accountNumber
+			}
+		}
+		if len(part) != s.length || !isUpperAlphanumeric(part) {
+			return "", ErrInvalidFormat
+		}
+		bban.WriteString(part)
+	}
+	check := 98 - mod97(bban.String()+countryCode+"00")
+	return fmt.Sprintf("%s%02d%s", countryCode, check, bban.String()), nil
+}
+
+func layoutLength(layout []segment) int {
+	length := 0
+	for _, s := range layout {
+		length += s.length
+	}
+	return length
+}
+
+// mod97 computes the ISO 7064 mod-97 of s, replacing letters with two digits numbers (A = 10, ..., Z = 35).
+func mod97(s string) int64 {
+	var digits strings.Builder
+	for _, r := range s {
+		if r >= 'A' && r <= 'Z' {
+			fmt.Fprintf(&digits, "%d", r-'A'+10)
+		} else {
+			digits.WriteRune(r)
+		}
+	}
+	n, _ := new(big.Int).SetString(digits.String(), 10)
+	return new(big.Int).Mod(n, big.NewInt(97)).Int64()
+}
+
+func isUpperAlpha(s string) bool {
+	for _, r := range s {
+		if r < 'A' || r > 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func isUpperAlphanumeric(s string) bool {
+	for _, r := range s {
+		if (r < 'A' || r > 'Z') && (r < '0' || r > '9') {
+			return false
+		}
+	}
+	return true
+}
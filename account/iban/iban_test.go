@@ -0,0 +1,93 @@
+package iban
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	subtests := []struct {
+		name    string
+		iban    string
+		expIban *IBAN
+		expErr  error
+	}{
+		{
+			name: "Valid GB IBAN",
+			iban: "GB29NWBK60161331926819",
+			expIban: &IBAN{CountryCode: "GB", CheckDigits: "29", BankCode: "NWBK", BranchCode: "601613",
+				AccountNumber: "31926819", BBAN: "NWBK60161331926819"},
+		},
+		{
+			name: "Valid FR IBAN with national check digits",
+			iban: "FR1420041010050500013M02606",
+			expIban: &IBAN{CountryCode: "FR", CheckDigits: "14", BankCode: "20041", BranchCode: "01005",
+				AccountNumber: "0500013M026", NationalCheckDigits: "06", BBAN: "20041010050500013M02606"},
+		},
+		{
+			name: "Valid DE IBAN",
+			iban: "DE89370400440532013000",
+			expIban: &IBAN{CountryCode: "DE", CheckDigits: "89", BankCode: "37040044",
+				AccountNumber: "0532013000", BBAN: "370400440532013000"},
+		},
+		{"Wrong checksum", "GB28NWBK60161331926819", nil, ErrInvalidChecksum},
+		{"Wrong length", "GB29NWBK6016133192681", nil, ErrInvalidLength},
+		{"Lower case", "gb29nwbk60161331926819", nil, ErrInvalidFormat},
+		{"Too short", "GB2", nil, ErrInvalidFormat},
+		{
+			name:    "Valid SE IBAN without known layout",
+			iban:    "SE4550000000058398257466",
+			expIban: &IBAN{CountryCode: "SE", CheckDigits: "45", BBAN: "50000000058398257466"},
+		},
+		{"Wrong checksum without known layout", "SE4450000000058398257466", nil, ErrInvalidChecksum},
+		{"Too long", "SE45500000000583982574665000000000000", nil, ErrInvalidFormat},
+	}
+	for _, subtest := range subtests {
+		t.Run(subtest.name, func(t *testing.T) {
+			result, err := Parse(subtest.iban)
+			if !errors.Is(err, subtest.expErr) {
+				t.Errorf("expected error (%v), got (%v)", subtest.expErr, err)
+			}
+			if !reflect.DeepEqual(result, subtest.expIban) {
+				t.Errorf("expected (%+v), got (%+v)", subtest.expIban, result)
+			}
+			if result != nil && result.String() != subtest.iban {
+				t.Errorf("expected String() (%s), got (%s)", subtest.iban, result.String())
+			}
+		})
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	subtests := []struct {
+		name          string
+		countryCode   string
+		bankCode      string
+		branchCode    string
+		accountNumber string
+		expIban       string
+		expErr        error
+	}{
+		{"GB IBAN", "GB", "NWBK", "601613", "31926819", "GB29NWBK60161331926819", nil},
+		{"DE IBAN with padded account number", "DE", "37040044", "", "532013000", "DE89370400440532013000", nil},
+		{"Country with national check digits", "FR", "20041", "01005", "0500013M026", "", ErrUnsupportedCountry},
+		{"Unknown country", "XX", "NWBK", "601613", "31926819", "", ErrUnsupportedCountry},
+		{"Wrong bank code length", "GB", "NWB", "601613", "31926819", "", ErrInvalidFormat},
+		{"Account number too long", "GB", "NWBK", "601613", "319268190", "", ErrInvalidFormat},
+	}
+	for _, subtest := range subtests {
+		t.Run(subtest.name, func(t *testing.T) {
+			result, err := Generate(subtest.countryCode, subtest.bankCode, subtest.branchCode, subtest.accountNumber)
+			if !errors.Is(err, subtest.expErr) {
+				t.Errorf("expected error (%v), got (%v)", subtest.expErr, err)
+			}
+			if result != subtest.expIban {
+				t.Errorf("expected IBAN (%s), got (%s)", subtest.expIban, result)
+			}
+			if err == nil && Validate(result) != nil {
+				t.Errorf("expected generated IBAN (%s) to be valid, got (%v)", result, Validate(result))
+			}
+		})
+	}
+}
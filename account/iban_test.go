@@ -0,0 +1,59 @@
+package account
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/edihoxhalli/Form3-exercise/account/iban"
+)
+
+func TestFillIban(t *testing.T) {
+	subtests := []struct {
+		name    string
+		attrs   AccountAttributes
+		expIban string
+		expErr  error
+	}{
+		{
+			name:    "GB account",
+			attrs:   AccountAttributes{Country: newStringPointer("GB"), BankID: "601613", Bic: "NWBKGB22", AccountNumber: "31926819"},
+			expIban: "GB29NWBK60161331926819",
+		},
+		{
+			name:    "DE account",
+			attrs:   AccountAttributes{Country: newStringPointer("DE"), BankID: "37040044", AccountNumber: "0532013000"},
+			expIban: "DE89370400440532013000",
+		},
+		{
+			name:   "GB account without BIC",
+			attrs:  AccountAttributes{Country: newStringPointer("GB"), BankID: "601613", AccountNumber: "31926819"},
+			expErr: errors.New(fill_iban_missing_fields_formatting),
+		},
+		{
+			name:   "FR account",
+			attrs:  AccountAttributes{Country: newStringPointer("FR"), BankID: "2004101005", AccountNumber: "0500013M026"},
+			expErr: iban.ErrUnsupportedCountry,
+		},
+	}
+	for _, subtest := range subtests {
+		t.Run(subtest.name, func(t *testing.T) {
+			attrs := subtest.attrs
+			err := attrs.FillIban()
+			if subtest.expErr != nil {
+				if err == nil || (!errors.Is(err, subtest.expErr) && err.Error() != subtest.expErr.Error()) {
+					t.Errorf("expected error (%v), got (%v)", subtest.expErr, err)
+				}
+				if attrs.Iban != "" {
+					t.Errorf("expected Iban to be left unchanged, got (%s)", attrs.Iban)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("expected nil error, got (%v)", err)
+			}
+			if attrs.Iban != subtest.expIban {
+				t.Errorf("expected Iban (%s), got (%s)", subtest.expIban, attrs.Iban)
+			}
+		})
+	}
+}
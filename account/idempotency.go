@@ -0,0 +1,75 @@
+package account
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+)
+
+// IdempotencyKeyHeader is the header carrying the idempotency key of a Create request.
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+type idempotencyKey struct{}
+
+// ContextWithIdempotencyKey returns a copy of ctx carrying key as the idempotency key
+// of the Create request made with it. See WithIdempotentCreate.
+func ContextWithIdempotencyKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, idempotencyKey{}, key)
+}
+
+// IdempotencyKeyFromContext returns the idempotency key carried by ctx, if any.
+func IdempotencyKeyFromContext(ctx context.Context) (string, bool) {
+	key, ok := ctx.Value(idempotencyKey{}).(string)
+	return key, ok && key != ""
+}
+
+// WithIdempotentCreate makes Create send an IdempotencyKeyHeader on every request,
+// generating a random key when none is given through ContextWithIdempotencyKey.
+// The same key is sent on every attempt of a Create, which may then be retried by
+// the RetryPolicy even without RetryCreate.
+//
+// Whenever Create is sent with an idempotency key, a 409 Conflict is resolved by
+// fetching the existing Account: if it matches the submitted AccountData, e.g. because
+// a previous attempt timed out after the Account was created, the existing Account
+// is returned along with the Status and Status Code of the Fetch.
+func WithIdempotentCreate() Option {
+	return func(c *Client) {
+		c.idempotentCreate = true
+	}
+}
+
+// createIdempotencyKey returns the idempotency key to send with a Create, if any.
+func (c *Client) createIdempotencyKey(ctx context.Context) string {
+	if key, ok := IdempotencyKeyFromContext(ctx); ok {
+		return key
+	}
+	if c.idempotentCreate {
+		return uuid.NewString()
+	}
+	return ""
+}
+
+// resolveCreateConflict fetches the Account conflicting with the created acc,
+// and returns it if it matches acc. Otherwise it returns nil, along with createErr.
+func (c *Client) resolveCreateConflict(ctx context.Context, acc Account, createErr error) (*AccountApiResponse, error) {
+	if acc.Data == nil || !errors.Is(createErr, ErrConflict) {
+		return nil, createErr
+	}
+	id, err := uuid.Parse(acc.Data.ID)
+	if err != nil {
+		return nil, createErr
+	}
+	existing, err := c.FetchContext(ctx, id)
+	if err != nil || existing.ResponseBody == nil || !matchesSubmitted(acc.Data, existing.ResponseBody.Data) {
+		return nil, createErr
+	}
+	return existing, nil
+}
+
+// matchesSubmitted reports whether the existing AccountData holds every field of the submitted one.
+// Fields left empty on submission, and fields set by the form3 API such as Version, are not compared.
+func matchesSubmitted(submitted, existing *AccountData) bool {
+	diffs, ok := diffSubmitted(submitted, existing)
+	return ok && len(diffs) == 0
+}
@@ -0,0 +1,100 @@
+package account_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/edihoxhalli/Form3-exercise/account"
+	"github.com/edihoxhalli/Form3-exercise/account/accounttest"
+	"github.com/google/uuid"
+)
+
+func TestIdempotentCreate(t *testing.T) {
+	server := accounttest.NewServer()
+	defer server.Close()
+
+	var keys []string
+	policy := account.DefaultRetryPolicy()
+	policy.BaseDelay, policy.MaxDelay = time.Millisecond, time.Millisecond
+	client := server.Client(account.WithIdempotentCreate(), account.WithRetryPolicy(policy),
+		account.WithMiddleware(func(next http.RoundTripper) http.RoundTripper {
+			return account.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				if req.Method == http.MethodPost {
+					keys = append(keys, req.Header.Get(account.IdempotencyKeyHeader))
+				}
+				return next.RoundTrip(req)
+			})
+		}))
+
+	t.Run("retried with the same key", func(t *testing.T) {
+		keys = nil
+		server.ScriptFaults(accounttest.EndpointCreate, accounttest.Fault{StatusCode: http.StatusServiceUnavailable})
+		if _, err := client.Create(testAccount(uuid.NewString())); err != nil {
+			t.Fatalf("unexpected error (%v)", err)
+		}
+		if len(keys) != 2 || keys[0] == "" || keys[0] != keys[1] {
+			t.Errorf("expected the same generated key on both attempts, got (%v)", keys)
+		}
+	})
+
+	t.Run("created by a timed out call", func(t *testing.T) {
+		acc := testAccount(uuid.NewString())
+		if _, err := server.Client().Create(acc); err != nil {
+			t.Fatalf("unexpected error (%v)", err)
+		}
+		res, err := client.Create(acc)
+		if err != nil {
+			t.Fatalf("unexpected error (%v)", err)
+		}
+		if res.ResponseBody.Data.ID != acc.Data.ID || res.StatusCode != http.StatusOK {
+			t.Errorf("expected the existing account from a fetch, got (%+v)", res)
+		}
+	})
+
+	t.Run("given key", func(t *testing.T) {
+		keys = nil
+		ctx := account.ContextWithIdempotencyKey(context.Background(), "create-42")
+		if _, err := client.CreateContext(ctx, testAccount(uuid.NewString())); err != nil {
+			t.Fatalf("unexpected error (%v)", err)
+		}
+		if len(keys) != 1 || keys[0] != "create-42" {
+			t.Errorf("expected the given key, got (%v)", keys)
+		}
+	})
+
+	t.Run("conflict with a different account", func(t *testing.T) {
+		acc := testAccount(uuid.NewString())
+		if _, err := client.Create(acc); err != nil {
+			t.Fatalf("unexpected error (%v)", err)
+		}
+		acc.Data.Attributes.BankID = "400301"
+		_, err := client.Create(acc)
+		if !errors.Is(err, account.ErrConflict) {
+			t.Errorf("expected ErrConflict, got (%v)", err)
+		}
+	})
+}
+
+func TestCreateWithoutIdempotencyKey(t *testing.T) {
+	server := accounttest.NewServer()
+	defer server.Close()
+	client := server.Client(account.WithMiddleware(func(next http.RoundTripper) http.RoundTripper {
+		return account.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			if key := req.Header.Get(account.IdempotencyKeyHeader); key != "" {
+				t.Errorf("expected no idempotency key, got (%s)", key)
+			}
+			return next.RoundTrip(req)
+		})
+	}))
+
+	acc := testAccount(uuid.NewString())
+	if _, err := client.Create(acc); err != nil {
+		t.Fatalf("unexpected error (%v)", err)
+	}
+	if _, err := client.Create(acc); !errors.Is(err, account.ErrConflict) {
+		t.Errorf("expected ErrConflict without idempotency key, got (%v)", err)
+	}
+}
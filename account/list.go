@@ -0,0 +1,240 @@
+package account
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/google/uuid"
+)
+
+// ListOptions holds the pagination and filtering parameters of a List operation.
+// Zero values are not sent, letting the form3 API apply its defaults.
+type ListOptions struct {
+	// PageNumber is the number of the page to retrieve, starting from 0.
+	PageNumber int
+	// PageSize is the number of accounts per page.
+	PageSize int
+	// Filter restricts the accounts returned.
+	Filter ListFilter
+}
+
+// ListFilter holds the filter[...] query parameters of a List operation.
+type ListFilter struct {
+	BankID        string
+	AccountNumber string
+	Iban          string
+	Country       string
+	CustomerID    string
+}
+
+// Links represents the links section of a paginated form3 API response.
+type Links struct {
+	First string `json:"first,omitempty"`
+	Last  string `json:"last,omitempty"`
+	Next  string `json:"next,omitempty"`
+	Prev  string `json:"prev,omitempty"`
+	Self  string `json:"self,omitempty"`
+}
+
+// AccountList represents a page of accounts in the form3 org section.
+type AccountList struct {
+	Data  []AccountData `json:"data"`
+	Links *Links        `json:"links,omitempty"`
+}
+
+// AccountListApiResponse represents the response gotten from calling the form3 org accounts list endpoint.
+type AccountListApiResponse struct {
+	ResponseBody *AccountList `json:"response_body,omitempty"`
+	StatusCode   int          `json:"status_code,omitempty"`
+	Status       string       `json:"status,omitempty"`
+}
+
+func (opts ListOptions) query() url.Values {
+	q := url.Values{}
+	if opts.PageNumber > 0 {
+		q.Set("page[number]", strconv.Itoa(opts.PageNumber))
+	}
+	if opts.PageSize > 0 {
+		q.Set("page[size]", strconv.Itoa(opts.PageSize))
+	}
+	filters := []struct {
+		name  string
+		value string
+	}{
+		{"bank_id", opts.Filter.BankID},
+		{"account_number", opts.Filter.AccountNumber},
+		{"iban", opts.Filter.Iban},
+		{"country", opts.Filter.Country},
+		{"customer_id", opts.Filter.CustomerID},
+	}
+	for _, f := range filters {
+		if f.value != "" {
+			q.Set("filter["+f.name+"]", f.value)
+		}
+	}
+	return q
+}
+
+// List enables to retrieve a page of Account records on the form3 API,
+// using the default Client.
+// See Client.List for more information.
+func List(opts ListOptions) (*AccountListApiResponse, error) {
+	return defaultClient().List(opts)
+}
+
+// ListContext is like List but carries a context.
+// See Client.ListContext for more information.
+func ListContext(ctx context.Context, opts ListOptions) (*AccountListApiResponse, error) {
+	return defaultClient().ListContext(ctx, opts)
+}
+
+// Iterate returns an AccountIterator over every Account matching opts,
+// using the default Client.
+// See Client.Iterate for more information.
+func Iterate(ctx context.Context, opts ListOptions) *AccountIterator {
+	return defaultClient().Iterate(ctx, opts)
+}
+
+// List enables to retrieve a page of Account records on the form3 API.
+// It takes as parameter the pagination and filtering options.
+// It returns an AccountListApiResponse pointer var with the retrieved page as ResponseBody,
+// including its links section, along with the Status and Status Code response details.
+// In case any error occurs while attempting to list the Accounts,
+// it returns nil, along with the error.
+func (c *Client) List(opts ListOptions) (*AccountListApiResponse, error) {
+	return c.ListContext(context.Background(), opts)
+}
+
+// ListContext is like List but carries a context.
+// If the context is canceled or its deadline is exceeded before the response
+// is received, it returns nil, along with a *RequestCanceledError.
+func (c *Client) ListContext(ctx context.Context, opts ListOptions) (*AccountListApiResponse, error) {
+	return c.listPage(ctx, opts.query().Encode())
+}
+
+func (c *Client) listPage(ctx context.Context, rawQuery string) (res *AccountListApiResponse, err error) {
+	ctx, end := c.startOperation(ctx, Operation{Name: OperationList})
+	defer func() { end(res.statusCode(), err) }()
+
+	req, err := newReq(ctx, c, listMethod, uuid.Nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.URL.RawQuery = rawQuery
+	response, err := apiCall(c, req)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+	return handleList(response)
+}
+
+var handleListResponse = func(response *http.Response) (*AccountListApiResponse, error) {
+	responseWrapper := AccountListApiResponse{
+		Status:     response.Status,
+		StatusCode: response.StatusCode,
+	}
+	responseBody, err := readRespBody(response.Body)
+	if err != nil {
+		return nil, err
+	}
+	if responseWrapper.StatusCode >= http.StatusBadRequest {
+		return nil, newApiError(responseWrapper.StatusCode, responseWrapper.Status, responseBody,
+			fmt.Sprintf(error_status_code_formatting, responseWrapper.StatusCode, responseWrapper.Status))
+	}
+	if responseWrapper.StatusCode != http.StatusOK {
+		return nil, newApiError(responseWrapper.StatusCode, responseWrapper.Status, responseBody,
+			fmt.Sprintf(list_incorrect_status_code_formatting, http.StatusOK, responseWrapper.StatusCode))
+	}
+	var list AccountList
+	err = jsonUnmarshal(responseBody, &list)
+	if err != nil {
+		return nil, err
+	}
+	responseWrapper.ResponseBody = &list
+	return &responseWrapper, nil
+}
+
+// AccountIterator walks lazily through every page of a List operation,
+// following the next link of each page.
+// It is not safe for concurrent use.
+//
+//	it := client.Iterate(ctx, account.ListOptions{PageSize: 100})
+//	for it.Next() {
+//		acc := it.Account()
+//		...
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+type AccountIterator struct {
+	c        *Client
+	ctx      context.Context
+	rawQuery string
+	page     []AccountData
+	index    int
+	current  *AccountData
+	done     bool
+	err      error
+}
+
+// Iterate returns an AccountIterator over every Account matching opts.
+// opts.PageNumber is the page the iteration starts from.
+// No request is made until Next is called.
+func (c *Client) Iterate(ctx context.Context, opts ListOptions) *AccountIterator {
+	return &AccountIterator{c: c, ctx: ctx, rawQuery: opts.query().Encode()}
+}
+
+// Next advances the iterator to the next Account, fetching the next page when needed.
+// It returns false when the iteration is over or an error occurred,
+// in which case Err returns the error.
+func (it *AccountIterator) Next() bool {
+	for it.index >= len(it.page) {
+		if it.done || it.err != nil {
+			it.current = nil
+			return false
+		}
+		it.fetchPage()
+	}
+	it.current = &it.page[it.index]
+	it.index++
+	return true
+}
+
+// Account returns the Account data the iterator currently points to.
+// It must only be called after a call to Next returning true.
+func (it *AccountIterator) Account() *AccountData {
+	return it.current
+}
+
+// Err returns the error which stopped the iteration, if any.
+func (it *AccountIterator) Err() error {
+	return it.err
+}
+
+func (it *AccountIterator) fetchPage() {
+	res, err := it.c.listPage(it.ctx, it.rawQuery)
+	if err != nil {
+		it.err = err
+		return
+	}
+	it.page = res.ResponseBody.Data
+	it.index = 0
+	if len(it.page) == 0 || res.ResponseBody.Links == nil || res.ResponseBody.Links.Next == "" {
+		it.done = true
+		return
+	}
+	next, err := url.Parse(res.ResponseBody.Links.Next)
+	if err != nil {
+		it.err = err
+		return
+	}
+	if next.RawQuery == it.rawQuery {
+		it.done = true
+		return
+	}
+	it.rawQuery = next.RawQuery
+}
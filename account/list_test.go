@@ -0,0 +1,217 @@
+package account
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func TestListOptionsQuery(t *testing.T) {
+	subtests := []struct {
+		name     string
+		opts     ListOptions
+		expQuery string
+	}{
+		{
+			name:     "Empty options",
+			expQuery: "",
+		},
+		{
+			name:     "Pagination only",
+			opts:     ListOptions{PageNumber: 2, PageSize: 50},
+			expQuery: "page%5Bnumber%5D=2&page%5Bsize%5D=50",
+		},
+		{
+			name: "Pagination and filters",
+			opts: ListOptions{
+				PageSize: 10,
+				Filter: ListFilter{
+					BankID:        "400300",
+					AccountNumber: "41426819",
+					Iban:          "GB11NWBK40030041426819",
+					Country:       "GB",
+					CustomerID:    "123",
+				},
+			},
+			expQuery: "filter%5Baccount_number%5D=41426819&filter%5Bbank_id%5D=400300&filter%5Bcountry%5D=GB" +
+				"&filter%5Bcustomer_id%5D=123&filter%5Biban%5D=GB11NWBK40030041426819&page%5Bsize%5D=10",
+		},
+	}
+	for _, subtest := range subtests {
+		t.Run(subtest.name, func(t *testing.T) {
+			result := subtest.opts.query().Encode()
+			if result != subtest.expQuery {
+				t.Errorf("expected query (%s), got (%s)", subtest.expQuery, result)
+			}
+		})
+	}
+}
+
+func TestHandleListResponse(t *testing.T) {
+	restoreStubs(t)
+
+	subtests := []struct {
+		name        string
+		response    *http.Response
+		expResponse *AccountListApiResponse
+		expError    error
+	}{
+		{
+			name: "Handle successful LIST response",
+			response: &http.Response{
+				Status:     "OK",
+				StatusCode: http.StatusOK,
+				Body: io.NopCloser(bytes.NewBufferString(
+					`{"data":[{"id":"ad27e265-9605-4b4b-a0e5-3003ea9cc4dc","type":"accounts"}],"links":{"self":"/v1/organisation/accounts"}}`)),
+			},
+			expResponse: &AccountListApiResponse{
+				ResponseBody: &AccountList{
+					Data:  []AccountData{{ID: "ad27e265-9605-4b4b-a0e5-3003ea9cc4dc", Type: "accounts"}},
+					Links: &Links{Self: "/v1/organisation/accounts"},
+				},
+				StatusCode: http.StatusOK,
+				Status:     "OK",
+			},
+		},
+		{
+			name: "Handle Status BAD REQUEST 400",
+			response: &http.Response{
+				Status:     "Bad request",
+				StatusCode: http.StatusBadRequest,
+				Body:       io.NopCloser(bytes.NewBufferString("invalid page")),
+			},
+			expError: &ApiError{
+				StatusCode:   http.StatusBadRequest,
+				Status:       "Bad request",
+				ResponseBody: "invalid page",
+				Message:      "GOT ERROR STATUS CODE OF 400, STATUS Bad request",
+			},
+		},
+		{
+			name: "Handle LIST response with incorrect status code",
+			response: &http.Response{
+				Status:     "No content",
+				StatusCode: http.StatusNoContent,
+				Body:       io.NopCloser(bytes.NewBufferString("")),
+			},
+			expError: &ApiError{
+				StatusCode:   http.StatusNoContent,
+				Status:       "No content",
+				ResponseBody: "",
+				Message:      "LIST OPERATION GOT INCORRECT STATUS CODE. EXPECTED: 200, GOT: 204",
+			},
+		},
+	}
+	for _, subtest := range subtests {
+		t.Run(subtest.name, func(t *testing.T) {
+			result, err := handleListResponse(subtest.response)
+			if err != nil && (subtest.expError == nil || subtest.expError.Error() != err.Error()) {
+				t.Errorf("expected error (%+v), got (%+v)", subtest.expError, err)
+			}
+			if !reflect.DeepEqual(result, subtest.expResponse) {
+				t.Errorf("expected (%+v), got (%+v)", subtest.expResponse, result)
+			}
+		})
+	}
+}
+
+// pagedApiCall serves total accounts split in pages of size pageSize,
+// with links built like the form3 API does.
+func pagedApiCall(total int, pageSize int, requestedPages *[]string) func(c *Client, req *http.Request) (*http.Response, error) {
+	return func(c *Client, req *http.Request) (*http.Response, error) {
+		page, _ := strconv.Atoi(req.URL.Query().Get("page[number]"))
+		*requestedPages = append(*requestedPages, req.URL.Query().Get("page[number]"))
+		list := AccountList{Data: []AccountData{}, Links: &Links{}}
+		for i := page * pageSize; i < total && i < (page+1)*pageSize; i++ {
+			list.Data = append(list.Data, AccountData{ID: strconv.Itoa(i)})
+		}
+		if (page+1)*pageSize < total {
+			list.Links.Next = fmt.Sprintf("/v1/organisation/accounts?page%%5Bnumber%%5D=%d&page%%5Bsize%%5D=%d", page+1, pageSize)
+		}
+		body, _ := json.Marshal(list)
+		return &http.Response{
+			Status:     "200 OK",
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(bytes.NewReader(body)),
+		}, nil
+	}
+}
+
+func TestIterate(t *testing.T) {
+	restoreStubs(t)
+
+	subtests := []struct {
+		name     string
+		total    int
+		pageSize int
+		expPages []string
+	}{
+		{
+			name:     "No accounts",
+			total:    0,
+			pageSize: 2,
+			expPages: []string{""},
+		},
+		{
+			name:     "Single page",
+			total:    2,
+			pageSize: 5,
+			expPages: []string{""},
+		},
+		{
+			name:     "Several pages",
+			total:    5,
+			pageSize: 2,
+			expPages: []string{"", "1", "2"},
+		},
+	}
+	for _, subtest := range subtests {
+		t.Run(subtest.name, func(t *testing.T) {
+			var requestedPages []string
+			apiCall = pagedApiCall(subtest.total, subtest.pageSize, &requestedPages)
+
+			it := NewClient().Iterate(context.Background(), ListOptions{PageSize: subtest.pageSize})
+			count := 0
+			for it.Next() {
+				if it.Account().ID != strconv.Itoa(count) {
+					t.Errorf("expected account id (%d), got (%s)", count, it.Account().ID)
+				}
+				count++
+			}
+			if it.Err() != nil {
+				t.Errorf("expected nil error, got (%v)", it.Err())
+			}
+			if count != subtest.total {
+				t.Errorf("expected (%d) accounts, got (%d)", subtest.total, count)
+			}
+			if !reflect.DeepEqual(requestedPages, subtest.expPages) {
+				t.Errorf("expected requested pages (%v), got (%v)", subtest.expPages, requestedPages)
+			}
+		})
+	}
+}
+
+func TestIterateStopsOnError(t *testing.T) {
+	restoreStubs(t)
+	apiCall = func(c *Client, req *http.Request) (*http.Response, error) {
+		return nil, errors.New("Failed to do api call")
+	}
+
+	it := NewClient().Iterate(context.Background(), ListOptions{})
+	if it.Next() {
+		t.Errorf("expected Next to return false")
+	}
+	if it.Err() == nil || it.Err().Error() != "Failed to do api call" {
+		t.Errorf("expected error (Failed to do api call), got (%v)", it.Err())
+	}
+	if it.Next() {
+		t.Errorf("expected Next to keep returning false after an error")
+	}
+}
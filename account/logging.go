@@ -0,0 +1,192 @@
+package account
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Redacted replaces the values of sensitive fields and headers in log records.
+const Redacted = "[REDACTED]"
+
+var (
+	// DefaultRedactedFields lists the JSON fields of request and response bodies,
+	// and the list filters, whose values are redacted when LogConfig.RedactedFields is nil.
+	DefaultRedactedFields = []string{"iban", "account_number", "name", "alternative_names", "secondary_identification"}
+	// DefaultRedactedHeaders lists the headers whose values are redacted when
+	// LogConfig.RedactedHeaders is nil.
+	DefaultRedactedHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie"}
+)
+
+// LogConfig configures the structured logging of a Client.
+type LogConfig struct {
+	// Logger receives the log records. Defaults to slog.Default().
+	Logger *slog.Logger
+	// LogHeaders adds the request and response headers to the log records.
+	LogHeaders bool
+	// LogBodies adds the request and response bodies to the log records.
+	LogBodies bool
+	// RedactedFields lists the JSON fields whose values are redacted from bodies and list filters.
+	// Defaults to DefaultRedactedFields.
+	RedactedFields []string
+	// RedactedHeaders lists the headers whose values are redacted. Defaults to DefaultRedactedHeaders.
+	RedactedHeaders []string
+}
+
+// WithLogging makes the Client log every attempt of every request, with its method, url, status,
+// latency, attempt number and request id. Successful attempts are logged at Info level,
+// transport errors and error status codes at Warn level.
+// When logging is enabled, requests without a RequestIDHeader get one, kept across retries,
+// so that log records can be correlated with the form3 API.
+func WithLogging(config LogConfig) Option {
+	return func(c *Client) {
+		if config.Logger == nil {
+			config.Logger = slog.Default()
+		}
+		if config.RedactedFields == nil {
+			config.RedactedFields = DefaultRedactedFields
+		}
+		if config.RedactedHeaders == nil {
+			config.RedactedHeaders = DefaultRedactedHeaders
+		}
+		c.logging = &config
+	}
+}
+
+// logAttempt executes the given attempt of req through doRequest, logging it if enabled.
+func logAttempt(c *Client, req *http.Request, attempt int) (*http.Response, error) {
+	config := c.logging
+	if config == nil {
+		return doRequest(c, req)
+	}
+	attrs := []slog.Attr{
+		slog.String("method", req.Method),
+		slog.String("url", config.redactURL(req)),
+		slog.Int("attempt", attempt),
+		slog.String("request_id", req.Header.Get(RequestIDHeader)),
+	}
+	if config.LogHeaders {
+		attrs = append(attrs, config.headers("request_headers", req.Header))
+	}
+	if config.LogBodies && req.GetBody != nil {
+		if body, err := req.GetBody(); err == nil {
+			content, _ := io.ReadAll(body)
+			body.Close()
+			attrs = append(attrs, slog.String("request_body", config.redactBody(content)))
+		}
+	}
+
+	start := time.Now()
+	response, err := doRequest(c, req)
+	attrs = append(attrs, slog.Duration("latency", time.Since(start)))
+	if err != nil {
+		attrs = append(attrs, slog.String("error", err.Error()))
+		config.Logger.LogAttrs(req.Context(), slog.LevelWarn, "account api request failed", attrs...)
+		return nil, err
+	}
+
+	attrs = append(attrs, slog.Int("status", response.StatusCode))
+	if config.LogHeaders {
+		attrs = append(attrs, config.headers("response_headers", response.Header))
+	}
+	if config.LogBodies && response.Body != nil {
+		content, readErr := io.ReadAll(response.Body)
+		response.Body.Close()
+		response.Body = io.NopCloser(bytes.NewReader(content))
+		if readErr != nil {
+			// the caller reading the body again gets the read error as well
+			response.Body = io.NopCloser(io.MultiReader(bytes.NewReader(content), errReader{readErr}))
+		}
+		attrs = append(attrs, slog.String("response_body", config.redactBody(content)))
+	}
+	level := slog.LevelInfo
+	if response.StatusCode >= http.StatusBadRequest {
+		level = slog.LevelWarn
+	}
+	config.Logger.LogAttrs(req.Context(), level, "account api request", attrs...)
+	return response, nil
+}
+
+// ensureRequestID sets a RequestIDHeader on req, if logging is enabled and req has none.
+func ensureRequestID(c *Client, req *http.Request) {
+	if c.logging != nil && req.Header.Get(RequestIDHeader) == "" {
+		req.Header.Set(RequestIDHeader, uuid.NewString())
+	}
+}
+
+type errReader struct {
+	err error
+}
+
+func (r errReader) Read([]byte) (int, error) {
+	return 0, r.err
+}
+
+func (config *LogConfig) redactURL(req *http.Request) string {
+	u := *req.URL
+	query := u.Query()
+	redacted := false
+	for _, field := range config.RedactedFields {
+		if key := "filter[" + field + "]"; query.Get(key) != "" {
+			query.Set(key, Redacted)
+			redacted = true
+		}
+	}
+	if redacted {
+		u.RawQuery = query.Encode()
+	}
+	return u.String()
+}
+
+func (config *LogConfig) headers(key string, header http.Header) slog.Attr {
+	attrs := make([]any, 0, len(header))
+	for name, values := range header {
+		value := strings.Join(values, ", ")
+		for _, redacted := range config.RedactedHeaders {
+			if strings.EqualFold(name, redacted) {
+				value = Redacted
+			}
+		}
+		attrs = append(attrs, slog.String(name, value))
+	}
+	return slog.Group(key, attrs...)
+}
+
+// redactBody returns the JSON body with the values of the redacted fields replaced, at any depth.
+// Bodies which are not valid JSON are returned as is.
+func (config *LogConfig) redactBody(body []byte) string {
+	var decoded interface{}
+	if len(body) == 0 || json.Unmarshal(body, &decoded) != nil {
+		return string(body)
+	}
+	redacted, err := json.Marshal(config.redactValue(decoded))
+	if err != nil {
+		return string(body)
+	}
+	return string(redacted)
+}
+
+func (config *LogConfig) redactValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, field := range v {
+			v[key] = config.redactValue(field)
+			for _, redacted := range config.RedactedFields {
+				if key == redacted {
+					v[key] = Redacted
+				}
+			}
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = config.redactValue(item)
+		}
+	}
+	return value
+}
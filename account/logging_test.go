@@ -0,0 +1,108 @@
+package account
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func decodeLogRecords(t *testing.T, buf *bytes.Buffer) []map[string]interface{} {
+	var records []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var record map[string]interface{}
+		if err := json.Unmarshal([]byte(line), &record); err != nil {
+			t.Fatalf("invalid log record (%s): %v", line, err)
+		}
+		records = append(records, record)
+	}
+	return records
+}
+
+func TestWithLogging(t *testing.T) {
+	restoreStubs(t)
+	sleep = func(ctx context.Context, d time.Duration) error { return nil }
+
+	var calls int32
+	var requestIDs []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestIDs = append(requestIDs, r.Header.Get(RequestIDHeader))
+		w.Header().Set("Content-Type", "application/vnd.api+json")
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			w.Write([]byte(`{"error_message":"unavailable"}`))
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"data":{"id":"ad27e265-9605-4b4b-a0e5-3003ea9cc4dc","attributes":{"iban":"GB33BUKB20201555555555","name":["Jane Doe"],"country":"GB"}}}`))
+	}))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	policy := DefaultRetryPolicy()
+	policy.RetryCreate = true
+	c := NewClient(WithHost(server.URL+"/"), WithRetryPolicy(policy),
+		WithAuthenticator(AuthenticatorFunc(func(req *http.Request) error {
+			req.Header.Set("Authorization", "Bearer secret-token")
+			return nil
+		})),
+		WithLogging(LogConfig{Logger: slog.New(slog.NewJSONHandler(&buf, nil)), LogHeaders: true, LogBodies: true}))
+
+	acc := test_acc
+	data := *acc.Data
+	attrs := *data.Attributes
+	attrs.AccountNumber = "41426819"
+	attrs.Name = []string{"Jane Doe"}
+	data.Attributes = &attrs
+	acc.Data = &data
+	if _, err := c.CreateContext(context.Background(), acc); err != nil {
+		t.Fatalf("unexpected error (%v)", err)
+	}
+
+	records := decodeLogRecords(t, &buf)
+	if len(records) != 2 {
+		t.Fatalf("expected 2 log records, got %d", len(records))
+	}
+	if requestIDs[0] == "" || requestIDs[0] != requestIDs[1] {
+		t.Errorf("expected the same request id on every attempt, got (%v)", requestIDs)
+	}
+	for i, record := range records {
+		if record["attempt"] != float64(i+1) || record["method"] != "POST" || record["request_id"] != requestIDs[0] {
+			t.Errorf("unexpected log record %d (%v)", i, record)
+		}
+		if _, ok := record["latency"]; !ok {
+			t.Errorf("expected latency in log record %d", i)
+		}
+	}
+	if records[0]["level"] != "WARN" || records[0]["status"] != float64(http.StatusServiceUnavailable) {
+		t.Errorf("unexpected first log record (%v)", records[0])
+	}
+	if records[1]["level"] != "INFO" || records[1]["status"] != float64(http.StatusCreated) {
+		t.Errorf("unexpected second log record (%v)", records[1])
+	}
+
+	logged := buf.String()
+	for _, secret := range []string{"secret-token", "GB33BUKB20201555555555", "41426819", "Jane Doe"} {
+		if strings.Contains(logged, secret) {
+			t.Errorf("expected (%s) to be redacted from the logs", secret)
+		}
+	}
+	if !strings.Contains(logged, `\"country\":\"GB\"`) {
+		t.Errorf("expected non sensitive body fields to be logged, got (%s)", logged)
+	}
+}
+
+func TestLogConfigRedactURL(t *testing.T) {
+	config := LogConfig{RedactedFields: DefaultRedactedFields}
+	req, _ := http.NewRequest(http.MethodGet, "http://localhost/v1/organisation/accounts?filter[iban]=GB33BUKB20201555555555&filter[country]=GB", nil)
+	got := config.redactURL(req)
+	if strings.Contains(got, "GB33BUKB20201555555555") || !strings.Contains(got, "filter%5Bcountry%5D=GB") {
+		t.Errorf("unexpected redacted url (%s)", got)
+	}
+}
@@ -0,0 +1,58 @@
+package account
+
+import (
+	"context"
+	"strconv"
+	"time"
+)
+
+// StatusClassError is the status class of operations which got no response, e.g. on transport errors.
+const StatusClassError = "error"
+
+// Metrics records measurements of the operations of a Client, e.g. as Prometheus metrics.
+// See package accountprom for a Prometheus implementation.
+// Implementations must be safe for concurrent use.
+type Metrics interface {
+	// OperationStarted is called when an operation starts, e.g. to count operations in flight.
+	OperationStarted(operation string)
+	// OperationDone is called once an operation is over, with the StatusClass of its response
+	// and its latency, including every retry.
+	OperationDone(operation, statusClass string, latency time.Duration)
+	// Retried is called before every retry of a request of an operation.
+	Retried(operation string)
+}
+
+// NopMetrics is the default Metrics of a Client, recording nothing.
+type NopMetrics struct{}
+
+func (NopMetrics) OperationStarted(string)                     {}
+func (NopMetrics) OperationDone(string, string, time.Duration) {}
+func (NopMetrics) Retried(string)                              {}
+
+// WithMetrics sets the Metrics recording the operations of the Client.
+// A nil metrics restores the default NopMetrics.
+func WithMetrics(metrics Metrics) Option {
+	return func(c *Client) {
+		if metrics == nil {
+			metrics = NopMetrics{}
+		}
+		c.metrics = metrics
+	}
+}
+
+// StatusClass returns the class of statusCode, e.g. "2xx" or "5xx",
+// or StatusClassError if there is no status code.
+func StatusClass(statusCode int) string {
+	if statusCode < 100 || statusCode > 599 {
+		return StatusClassError
+	}
+	return strconv.Itoa(statusCode/100) + "xx"
+}
+
+type operationKey struct{}
+
+// operationName returns the name of the operation ctx was started for, if any.
+func operationName(ctx context.Context) string {
+	op, _ := ctx.Value(operationKey{}).(Operation)
+	return op.Name
+}
@@ -0,0 +1,19 @@
+package account
+
+import "testing"
+
+func TestStatusClass(t *testing.T) {
+	subtests := map[int]string{
+		0:   StatusClassError,
+		200: "2xx",
+		204: "2xx",
+		409: "4xx",
+		503: "5xx",
+		600: StatusClassError,
+	}
+	for statusCode, expected := range subtests {
+		if got := StatusClass(statusCode); got != expected {
+			t.Errorf("expected status class (%s) for %d, got (%s)", expected, statusCode, got)
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package account
+
+import (
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RequestIDHeader is the header set by RequestIDMiddleware.
+const RequestIDHeader = "X-Request-ID"
+
+// RoundTripperFunc is an adapter allowing the use of ordinary functions as http.RoundTripper.
+type RoundTripperFunc func(req *http.Request) (*http.Response, error)
+
+func (f RoundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// Middleware wraps the http.RoundTripper executing the requests of a Client,
+// e.g. to log, measure or decorate every request.
+// As for any http.RoundTripper, a Middleware must not modify the request it is given,
+// but a clone of it.
+type Middleware func(next http.RoundTripper) http.RoundTripper
+
+// WithMiddleware appends middleware to the chain of the Client. The first middleware
+// of the chain is the outermost one, i.e. the first to see every request.
+// Middleware run on every attempt of a request, after its authentication.
+// The http client given through WithHttpClient is not modified: the Client uses a copy of it
+// whose Transport is wrapped by the chain.
+func WithMiddleware(middleware ...Middleware) Option {
+	return func(c *Client) {
+		c.middleware = append(c.middleware, middleware...)
+	}
+}
+
+// chain returns a copy of httpClient whose Transport is wrapped by middleware.
+func chain(httpClient *http.Client, middleware []Middleware) *http.Client {
+	if len(middleware) == 0 {
+		return httpClient
+	}
+	transport := httpClient.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	for i := len(middleware) - 1; i >= 0; i-- {
+		transport = middleware[i](transport)
+	}
+	wrapped := *httpClient
+	wrapped.Transport = transport
+	return &wrapped
+}
+
+// LoggingMiddleware logs the method, url, status and latency of every request to logger,
+// or to log.Default() if logger is nil, as one line of text, for programs logging through
+// the log package. WithLogging logs structured records through slog instead, with the
+// attempt number and request id, and optionally the headers and bodies.
+// As with WithLogging, the list filters of DefaultRedactedFields are redacted from the url.
+func LoggingMiddleware(logger *log.Logger) Middleware {
+	if logger == nil {
+		logger = log.Default()
+	}
+	config := &LogConfig{RedactedFields: DefaultRedactedFields}
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			start := time.Now()
+			response, err := next.RoundTrip(req)
+			if err != nil {
+				logger.Printf("%s %s failed after %v: %v", req.Method, config.redactURL(req), time.Since(start), err)
+				return nil, err
+			}
+			logger.Printf("%s %s %s in %v", req.Method, config.redactURL(req), response.Status, time.Since(start))
+			return response, nil
+		})
+	}
+}
+
+// RequestIDMiddleware sets the RequestIDHeader of requests which do not have one yet
+// to a new random UUID.
+func RequestIDMiddleware() Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			if req.Header.Get(RequestIDHeader) != "" {
+				return next.RoundTrip(req)
+			}
+			clone := req.Clone(req.Context())
+			clone.Header.Set(RequestIDHeader, uuid.NewString())
+			return next.RoundTrip(clone)
+		})
+	}
+}
+
+// UserAgentMiddleware sets the User-Agent header of every request to userAgent.
+func UserAgentMiddleware(userAgent string) Middleware {
+	return HeaderMiddleware(http.Header{"User-Agent": {userAgent}})
+}
+
+// HeaderMiddleware sets the given headers on every request, replacing any existing value.
+func HeaderMiddleware(header http.Header) Middleware {
+	header = header.Clone()
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			clone := req.Clone(req.Context())
+			for name, values := range header {
+				clone.Header[http.CanonicalHeaderKey(name)] = append([]string(nil), values...)
+			}
+			return next.RoundTrip(clone)
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package account
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestWithMiddlewareOrder(t *testing.T) {
+	var seen http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen = r.Header.Clone()
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	var order []string
+	trace := func(name string) Middleware {
+		return func(next http.RoundTripper) http.RoundTripper {
+			return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				order = append(order, name)
+				return next.RoundTrip(req)
+			})
+		}
+	}
+	httpClient := &http.Client{}
+	c := NewClient(WithHost(server.URL+"/"), WithHttpClient(httpClient),
+		WithMiddleware(trace("first"), UserAgentMiddleware("accountlib/test")),
+		WithMiddleware(RequestIDMiddleware(), HeaderMiddleware(http.Header{"x-tenant": {"acme"}}), trace("last")))
+
+	if _, err := c.DeleteContext(context.Background(), uuid.New(), 0); err != nil {
+		t.Fatalf("unexpected error (%v)", err)
+	}
+	if strings.Join(order, ",") != "first,last" {
+		t.Errorf("expected middleware to run in order (first,last), got (%s)", strings.Join(order, ","))
+	}
+	if seen.Get("User-Agent") != "accountlib/test" {
+		t.Errorf("expected User-Agent (accountlib/test), got (%s)", seen.Get("User-Agent"))
+	}
+	if seen.Get("X-Tenant") != "acme" {
+		t.Errorf("expected X-Tenant (acme), got (%s)", seen.Get("X-Tenant"))
+	}
+	if _, err := uuid.Parse(seen.Get(RequestIDHeader)); err != nil {
+		t.Errorf("expected a uuid %s, got (%s)", RequestIDHeader, seen.Get(RequestIDHeader))
+	}
+	if httpClient.Transport != nil {
+		t.Error("expected the given http client not to be modified")
+	}
+}
+
+func TestRequestIDMiddlewareKeepsExistingID(t *testing.T) {
+	var got string
+	rt := RequestIDMiddleware()(RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		got = req.Header.Get(RequestIDHeader)
+		return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}, nil
+	}))
+	req, _ := http.NewRequest(http.MethodGet, "http://localhost/", nil)
+	req.Header.Set(RequestIDHeader, "given-id")
+	rt.RoundTrip(req)
+	if got != "given-id" {
+		t.Errorf("expected request id (given-id), got (%s)", got)
+	}
+}
+
+func TestLoggingMiddleware(t *testing.T) {
+	var buf bytes.Buffer
+	rt := LoggingMiddleware(log.New(&buf, "", 0))(RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusOK, Status: "200 OK", Body: http.NoBody}, nil
+	}))
+	req, _ := http.NewRequest(http.MethodGet, "http://localhost/v1/organisation/accounts", nil)
+	if _, err := rt.RoundTrip(req); err != nil {
+		t.Fatalf("unexpected error (%v)", err)
+	}
+	if !strings.HasPrefix(buf.String(), "GET http://localhost/v1/organisation/accounts 200 OK in ") {
+		t.Errorf("unexpected log line (%s)", buf.String())
+	}
+
+	buf.Reset()
+	req, _ = http.NewRequest(http.MethodGet, "http://localhost/v1/organisation/accounts?filter%5Biban%5D=GB29NWBK60161331926819", nil)
+	if _, err := rt.RoundTrip(req); err != nil {
+		t.Fatalf("unexpected error (%v)", err)
+	}
+	if strings.Contains(buf.String(), "GB29NWBK60161331926819") || !strings.Contains(buf.String(), "filter%5Biban%5D=%5BREDACTED%5D") {
+		t.Errorf("expected redacted iban filter, got (%s)", buf.String())
+	}
+}
@@ -23,19 +23,19 @@ type AccountData struct {
 }
 
 type AccountAttributes struct {
-	AccountClassification   *string  `json:"account_classification,omitempty"`
-	AccountMatchingOptOut   *bool    `json:"account_matching_opt_out,omitempty"`
-	AccountNumber           string   `json:"account_number,omitempty"`
-	AlternativeNames        []string `json:"alternative_names,omitempty"`
-	BankID                  string   `json:"bank_id,omitempty"`
-	BankIDCode              string   `json:"bank_id_code,omitempty"`
-	BaseCurrency            string   `json:"base_currency,omitempty"`
-	Bic                     string   `json:"bic,omitempty"`
-	Country                 *string  `json:"country,omitempty"`
-	Iban                    string   `json:"iban,omitempty"`
-	JointAccount            *bool    `json:"joint_account,omitempty"`
-	Name                    []string `json:"name,omitempty"`
-	SecondaryIdentification string   `json:"secondary_identification,omitempty"`
-	Status                  *string  `json:"status,omitempty"`
-	Switched                *bool    `json:"switched,omitempty"`
+	AccountClassification   *Classification `json:"account_classification,omitempty"`
+	AccountMatchingOptOut   *bool           `json:"account_matching_opt_out,omitempty"`
+	AccountNumber           string          `json:"account_number,omitempty"`
+	AlternativeNames        []string        `json:"alternative_names,omitempty"`
+	BankID                  string          `json:"bank_id,omitempty"`
+	BankIDCode              BankIDCode      `json:"bank_id_code,omitempty"`
+	BaseCurrency            string          `json:"base_currency,omitempty"`
+	Bic                     string          `json:"bic,omitempty"`
+	Country                 *string         `json:"country,omitempty"`
+	Iban                    string          `json:"iban,omitempty"`
+	JointAccount            *bool           `json:"joint_account,omitempty"`
+	Name                    []string        `json:"name,omitempty"`
+	SecondaryIdentification string          `json:"secondary_identification,omitempty"`
+	Status                  *Status         `json:"status,omitempty"`
+	Switched                *bool           `json:"switched,omitempty"`
 }
@@ -0,0 +1,192 @@
+package account
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultTokenExpiryDelta is how long before its expiry a cached token is refreshed.
+	DefaultTokenExpiryDelta = time.Duration(10) * time.Second
+	token_error_formatting  = "TOKEN REQUEST FAILED\nSTATUS CODE : %d\nRESPONSE BODY : %s"
+)
+
+// Token is an OAuth2 access token.
+type Token struct {
+	AccessToken string
+	TokenType   string
+	// Expiry is the time the token expires at. A zero Expiry never expires.
+	Expiry time.Time
+}
+
+// TokenSource supplies the tokens sent as bearer tokens by a Client.
+// It must be safe for concurrent use.
+type TokenSource interface {
+	Token(ctx context.Context) (*Token, error)
+}
+
+// TokenInvalidator is implemented by token sources able to drop a cached token
+// rejected by the API, so that the next call to Token fetches a new one.
+type TokenInvalidator interface {
+	InvalidateToken(accessToken string)
+}
+
+// WithTokenSource makes the Client send the tokens of ts in the Authorization header,
+// as bearer tokens. When a request is rejected with status 401 Unauthorized,
+// the token is invalidated, if ts implements TokenInvalidator, and the request is retried once.
+// It replaces any Authenticator set through WithAuthenticator.
+func WithTokenSource(ts TokenSource) Option {
+	return WithAuthenticator(&BearerTokenAuth{ts})
+}
+
+// BearerTokenAuth is an Authenticator sending the tokens of a TokenSource as bearer tokens.
+type BearerTokenAuth struct {
+	Source TokenSource
+}
+
+func (a *BearerTokenAuth) Authenticate(req *http.Request) error {
+	token, err := a.Source.Token(req.Context())
+	if err != nil {
+		return err
+	}
+	tokenType := token.TokenType
+	if tokenType == "" || strings.EqualFold(tokenType, "bearer") {
+		tokenType = "Bearer"
+	}
+	req.Header.Set("Authorization", tokenType+" "+token.AccessToken)
+	return nil
+}
+
+// invalidate drops the token sent with req from the TokenSource, if it supports it.
+// It reports whether the request can be retried with a new token.
+func (a *BearerTokenAuth) invalidate(req *http.Request) bool {
+	invalidator, ok := a.Source.(TokenInvalidator)
+	if !ok {
+		return false
+	}
+	authorization := req.Header.Get("Authorization")
+	if i := strings.IndexByte(authorization, ' '); i >= 0 {
+		invalidator.InvalidateToken(authorization[i+1:])
+	}
+	return true
+}
+
+// TokenError is returned when the token endpoint rejects a token request.
+type TokenError struct {
+	StatusCode   int
+	ResponseBody string
+}
+
+func (e *TokenError) Error() string {
+	return fmt.Sprintf(token_error_formatting, e.StatusCode, e.ResponseBody)
+}
+
+// ClientCredentialsTokenSource is a TokenSource performing the OAuth2 client credentials grant.
+// The token is cached until ExpiryDelta before its expiry. Concurrent callers share
+// a single token request when the token needs to be refreshed.
+type ClientCredentialsTokenSource struct {
+	// TokenURL is the url of the token endpoint.
+	TokenURL     string
+	ClientID     string
+	ClientSecret string
+	Scopes       []string
+	// HttpClient executes the token requests. Defaults to an http client with a timeout of DefaultTimeout.
+	HttpClient *http.Client
+	// ExpiryDelta defaults to DefaultTokenExpiryDelta.
+	ExpiryDelta time.Duration
+
+	mu    sync.Mutex
+	token *Token
+}
+
+// Token returns the cached token, or requests a new one if it is missing or about to expire.
+func (s *ClientCredentialsTokenSource) Token(ctx context.Context) (*Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.token != nil && s.valid(s.token) {
+		return s.token, nil
+	}
+	token, err := s.requestToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+	s.token = token
+	return token, nil
+}
+
+// InvalidateToken drops the cached token if it is accessToken.
+func (s *ClientCredentialsTokenSource) InvalidateToken(accessToken string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.token != nil && s.token.AccessToken == accessToken {
+		s.token = nil
+	}
+}
+
+func (s *ClientCredentialsTokenSource) valid(token *Token) bool {
+	if token.Expiry.IsZero() {
+		return true
+	}
+	delta := s.ExpiryDelta
+	if delta == 0 {
+		delta = DefaultTokenExpiryDelta
+	}
+	return timeNow().Add(delta).Before(token.Expiry)
+}
+
+func (s *ClientCredentialsTokenSource) requestToken(ctx context.Context) (*Token, error) {
+	form := url.Values{"grant_type": {"client_credentials"}}
+	if len(s.Scopes) > 0 {
+		form.Set("scope", strings.Join(s.Scopes, " "))
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.TokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.SetBasicAuth(url.QueryEscape(s.ClientID), url.QueryEscape(s.ClientSecret))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	httpClient := s.HttpClient
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: DefaultTimeout}
+	}
+	response, err := httpClient.Do(req)
+	if err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, &RequestCanceledError{ctxErr}
+		}
+		return nil, err
+	}
+	defer response.Body.Close()
+	body, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return nil, err
+	}
+	if response.StatusCode != http.StatusOK {
+		return nil, &TokenError{response.StatusCode, string(body)}
+	}
+	var payload struct {
+		AccessToken string `json:"access_token"`
+		TokenType   string `json:"token_type"`
+		ExpiresIn   int64  `json:"expires_in"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, err
+	}
+	if payload.AccessToken == "" {
+		return nil, &TokenError{response.StatusCode, string(body)}
+	}
+	token := &Token{AccessToken: payload.AccessToken, TokenType: payload.TokenType}
+	if payload.ExpiresIn > 0 {
+		token.Expiry = timeNow().Add(time.Duration(payload.ExpiresIn) * time.Second)
+	}
+	return token, nil
+}
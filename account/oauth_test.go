@@ -0,0 +1,165 @@
+package account
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newTokenServer(t *testing.T, expiresIn int, requests *int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, secret, ok := r.BasicAuth()
+		if !ok || id != "client" || secret != "secret" || r.FormValue("grant_type") != "client_credentials" {
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(w, `{"error":"invalid_client"}`)
+			return
+		}
+		n := atomic.AddInt32(requests, 1)
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"access_token":"token-%d","token_type":"bearer","expires_in":%d}`, n, expiresIn)
+	}))
+}
+
+func TestClientCredentialsTokenSource(t *testing.T) {
+	restoreStubs(t)
+	now := time.Date(2022, time.March, 1, 10, 0, 0, 0, time.UTC)
+	timeNow = func() time.Time { return now }
+
+	var requests int32
+	server := newTokenServer(t, 60, &requests)
+	defer server.Close()
+	ts := &ClientCredentialsTokenSource{TokenURL: server.URL, ClientID: "client", ClientSecret: "secret"}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := ts.Token(context.Background()); err != nil {
+				t.Errorf("unexpected error (%v)", err)
+			}
+		}()
+	}
+	wg.Wait()
+	if requests != 1 {
+		t.Errorf("expected 1 token request for concurrent callers, got %d", requests)
+	}
+
+	now = now.Add(time.Duration(45) * time.Second)
+	token, _ := ts.Token(context.Background())
+	if token.AccessToken != "token-1" {
+		t.Errorf("expected cached token (token-1), got (%s)", token.AccessToken)
+	}
+
+	now = now.Add(time.Duration(10) * time.Second)
+	token, _ = ts.Token(context.Background())
+	if token.AccessToken != "token-2" {
+		t.Errorf("expected refreshed token (token-2) within expiry delta, got (%s)", token.AccessToken)
+	}
+
+	ts.InvalidateToken("token-1")
+	token, _ = ts.Token(context.Background())
+	if token.AccessToken != "token-2" {
+		t.Errorf("expected stale invalidation to be ignored, got (%s)", token.AccessToken)
+	}
+	ts.InvalidateToken("token-2")
+	token, _ = ts.Token(context.Background())
+	if token.AccessToken != "token-3" {
+		t.Errorf("expected new token after invalidation (token-3), got (%s)", token.AccessToken)
+	}
+}
+
+func TestClientCredentialsTokenSourceError(t *testing.T) {
+	var requests int32
+	server := newTokenServer(t, 60, &requests)
+	defer server.Close()
+	ts := &ClientCredentialsTokenSource{TokenURL: server.URL, ClientID: "client", ClientSecret: "wrong"}
+
+	_, err := ts.Token(context.Background())
+	var tokenErr *TokenError
+	if !errors.As(err, &tokenErr) || tokenErr.StatusCode != http.StatusUnauthorized {
+		t.Errorf("expected TokenError with status 401, got (%v)", err)
+	}
+}
+
+func TestBearerTokenRetriedOnceOnUnauthorized(t *testing.T) {
+	var tokenRequests int32
+	tokenServer := newTokenServer(t, 3600, &tokenRequests)
+	defer tokenServer.Close()
+
+	subtests := []struct {
+		name              string
+		acceptedToken     string
+		expStatusCode     int
+		expApiRequests    int32
+		expTokenRequests  int32
+		expAuthorizations []string
+	}{
+		{
+			name:              "Valid token",
+			acceptedToken:     "token-1",
+			expStatusCode:     http.StatusOK,
+			expApiRequests:    1,
+			expTokenRequests:  1,
+			expAuthorizations: []string{"Bearer token-1"},
+		},
+		{
+			name:              "Revoked token refreshed",
+			acceptedToken:     "token-2",
+			expStatusCode:     http.StatusOK,
+			expApiRequests:    2,
+			expTokenRequests:  2,
+			expAuthorizations: []string{"Bearer token-1", "Bearer token-2"},
+		},
+		{
+			name:              "Refreshed token rejected too",
+			acceptedToken:     "none",
+			expStatusCode:     http.StatusUnauthorized,
+			expApiRequests:    2,
+			expTokenRequests:  2,
+			expAuthorizations: []string{"Bearer token-1", "Bearer token-2"},
+		},
+	}
+	for _, subtest := range subtests {
+		t.Run(subtest.name, func(t *testing.T) {
+			atomic.StoreInt32(&tokenRequests, 0)
+			var apiRequests int32
+			var authorizations []string
+			apiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				atomic.AddInt32(&apiRequests, 1)
+				authorizations = append(authorizations, r.Header.Get("Authorization"))
+				if r.Header.Get("Authorization") != "Bearer "+subtest.acceptedToken {
+					w.WriteHeader(http.StatusUnauthorized)
+					return
+				}
+				w.WriteHeader(http.StatusOK)
+			}))
+			defer apiServer.Close()
+
+			ts := &ClientCredentialsTokenSource{TokenURL: tokenServer.URL, ClientID: "client", ClientSecret: "secret"}
+			c := NewClient(WithTokenSource(ts))
+			req, _ := http.NewRequest(http.MethodGet, apiServer.URL, nil)
+			response, err := doRequest(c, req)
+			if err != nil {
+				t.Fatalf("unexpected error (%v)", err)
+			}
+			defer response.Body.Close()
+			if response.StatusCode != subtest.expStatusCode {
+				t.Errorf("expected status code (%d), got (%d)", subtest.expStatusCode, response.StatusCode)
+			}
+			if apiRequests != subtest.expApiRequests || tokenRequests != subtest.expTokenRequests {
+				t.Errorf("expected (%d) api and (%d) token requests, got (%d) and (%d)",
+					subtest.expApiRequests, subtest.expTokenRequests, apiRequests, tokenRequests)
+			}
+			if fmt.Sprint(authorizations) != fmt.Sprint(subtest.expAuthorizations) {
+				t.Errorf("expected authorizations (%v), got (%v)", subtest.expAuthorizations, authorizations)
+			}
+		})
+	}
+}
@@ -0,0 +1,162 @@
+package account
+
+import (
+	"context"
+	"io"
+	"math"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	// rateDecreaseFactor divides the rate of a RateLimiter on every 429 Too Many Requests.
+	rateDecreaseFactor = 2
+	// rateIncreaseStep is the fraction of RateLimit.Rate recovered on every other response.
+	rateIncreaseStep = 0.05
+)
+
+// RateLimit configures a RateLimiter.
+type RateLimit struct {
+	// Rate is the sustained number of requests per second. Zero disables the rate limiting.
+	Rate float64
+	// Burst is the number of requests which can be sent at once, on top of Rate. Defaults to 1.
+	Burst int
+	// MaxInFlight caps the number of concurrent requests. A request is in flight
+	// until its response body is closed. Zero means no cap.
+	MaxInFlight int
+	// MinRate is the lowest rate the RateLimiter slows down to on 429 responses.
+	// Defaults to a tenth of Rate.
+	MinRate float64
+}
+
+// RateLimiter limits the requests of one or more Clients, through a token bucket refilled at
+// the rate of its RateLimit, and a semaphore capping the number of requests in flight.
+// Every attempt of a request waits for both, until its context is done.
+//
+// The RateLimiter adapts to the form3 API: on every 429 Too Many Requests it halves its rate,
+// down to RateLimit.MinRate, and it recovers 5% of RateLimit.Rate on every other response.
+// It is safe for concurrent use.
+type RateLimiter struct {
+	limit RateLimit
+	slots chan struct{}
+
+	mu     sync.Mutex
+	rate   float64
+	tokens float64
+	last   time.Time
+}
+
+// NewRateLimiter returns a RateLimiter configured with limit, starting with a full bucket.
+func NewRateLimiter(limit RateLimit) *RateLimiter {
+	if limit.Burst < 1 {
+		limit.Burst = 1
+	}
+	if limit.MinRate <= 0 || limit.MinRate > limit.Rate {
+		limit.MinRate = limit.Rate / 10
+	}
+	l := &RateLimiter{
+		limit:  limit,
+		rate:   limit.Rate,
+		tokens: float64(limit.Burst),
+		last:   timeNow(),
+	}
+	if limit.MaxInFlight > 0 {
+		l.slots = make(chan struct{}, limit.MaxInFlight)
+	}
+	return l
+}
+
+// WithRateLimiter makes the Client wait for limiter before every attempt of a request.
+// The same RateLimiter can be shared by several Clients, to limit them together.
+func WithRateLimiter(limiter *RateLimiter) Option {
+	return func(c *Client) {
+		c.rateLimiter = limiter
+	}
+}
+
+// Rate returns the current rate of the RateLimiter, in requests per second.
+func (l *RateLimiter) Rate() float64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.rate
+}
+
+// acquire waits for a slot and a token, and returns the func releasing the slot.
+// It returns a *RequestCanceledError if ctx is done first.
+func (l *RateLimiter) acquire(ctx context.Context) (func(), error) {
+	release := func() {}
+	if l.slots != nil {
+		select {
+		case l.slots <- struct{}{}:
+			release = func() { <-l.slots }
+		case <-ctx.Done():
+			return nil, &RequestCanceledError{ctx.Err()}
+		}
+	}
+	if delay := l.reserve(); delay > 0 {
+		if err := sleep(ctx, delay); err != nil {
+			l.unreserve()
+			release()
+			return nil, err
+		}
+	}
+	return release, nil
+}
+
+// reserve takes a token from the bucket, and returns how long to wait before it is available.
+func (l *RateLimiter) reserve() time.Duration {
+	if l.limit.Rate <= 0 {
+		return 0
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.refill()
+	l.tokens--
+	if l.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-l.tokens / l.rate * float64(time.Second))
+}
+
+// unreserve gives back a token taken by a reservation which was not used.
+func (l *RateLimiter) unreserve() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.tokens++
+}
+
+// observe adapts the rate to the status code of a response.
+func (l *RateLimiter) observe(statusCode int) {
+	if l.limit.Rate <= 0 || statusCode == 0 {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.refill()
+	if statusCode == http.StatusTooManyRequests {
+		l.rate = math.Max(l.limit.MinRate, l.rate/rateDecreaseFactor)
+		return
+	}
+	l.rate = math.Min(l.limit.Rate, l.rate+l.limit.Rate*rateIncreaseStep)
+}
+
+// refill adds the tokens accrued since the last refill. It must be called with mu held.
+func (l *RateLimiter) refill() {
+	now := timeNow()
+	l.tokens = math.Min(float64(l.limit.Burst), l.tokens+now.Sub(l.last).Seconds()*l.rate)
+	l.last = now
+}
+
+// releaseOnClose releases the in flight slot of a response once its body is closed.
+type releaseOnClose struct {
+	io.ReadCloser
+	once    sync.Once
+	release func()
+}
+
+func (b *releaseOnClose) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(b.release)
+	return err
+}
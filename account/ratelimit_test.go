@@ -0,0 +1,170 @@
+package account
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// fakeClock stubs timeNow and sleep with a clock which only advances when sleeping.
+func fakeClock(t *testing.T) *[]time.Duration {
+	restoreStubs(t)
+	now := time.Date(2022, time.March, 1, 10, 0, 0, 0, time.UTC)
+	var mu sync.Mutex
+	var sleeps []time.Duration
+	timeNow = func() time.Time {
+		mu.Lock()
+		defer mu.Unlock()
+		return now
+	}
+	sleep = func(ctx context.Context, d time.Duration) error {
+		mu.Lock()
+		defer mu.Unlock()
+		sleeps = append(sleeps, d)
+		now = now.Add(d)
+		return nil
+	}
+	return &sleeps
+}
+
+func TestRateLimiterTokenBucket(t *testing.T) {
+	sleeps := fakeClock(t)
+	l := NewRateLimiter(RateLimit{Rate: 10, Burst: 2})
+
+	for i := 0; i < 4; i++ {
+		release, err := l.acquire(context.Background())
+		if err != nil {
+			t.Fatalf("unexpected error (%v)", err)
+		}
+		release()
+	}
+	expected := []time.Duration{time.Duration(100) * time.Millisecond, time.Duration(100) * time.Millisecond}
+	if len(*sleeps) != len(expected) || (*sleeps)[0] != expected[0] || (*sleeps)[1] != expected[1] {
+		t.Errorf("expected waits (%v) once the burst is spent, got (%v)", expected, *sleeps)
+	}
+}
+
+func TestRateLimiterCanceled(t *testing.T) {
+	restoreStubs(t)
+	l := NewRateLimiter(RateLimit{Rate: 1, MaxInFlight: 1})
+	release, err := l.acquire(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error (%v)", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(10)*time.Millisecond)
+	defer cancel()
+	var canceledErr *RequestCanceledError
+	if _, err := l.acquire(ctx); !errors.As(err, &canceledErr) {
+		t.Errorf("expected *RequestCanceledError while no slot is free, got (%v)", err)
+	}
+
+	release()
+	ctx, cancel = context.WithTimeout(context.Background(), time.Duration(10)*time.Millisecond)
+	defer cancel()
+	if _, err := l.acquire(ctx); !errors.As(err, &canceledErr) {
+		t.Errorf("expected *RequestCanceledError while waiting for a token, got (%v)", err)
+	}
+	if l.tokens < -0.5 {
+		t.Errorf("expected the canceled reservation to be given back, got (%v) tokens", l.tokens)
+	}
+}
+
+func TestRateLimiterAdapts(t *testing.T) {
+	fakeClock(t)
+	l := NewRateLimiter(RateLimit{Rate: 100})
+
+	for i := 0; i < 5; i++ {
+		l.observe(http.StatusTooManyRequests)
+	}
+	if rate := l.Rate(); rate != 10 {
+		t.Errorf("expected the rate to stop at MinRate (10), got (%v)", rate)
+	}
+	l.observe(http.StatusCreated)
+	if rate := l.Rate(); rate != 15 {
+		t.Errorf("expected the rate to recover by 5%% of Rate (15), got (%v)", rate)
+	}
+	for i := 0; i < 30; i++ {
+		l.observe(http.StatusOK)
+	}
+	if rate := l.Rate(); rate != 100 {
+		t.Errorf("expected the rate to recover up to Rate (100), got (%v)", rate)
+	}
+}
+
+func TestWithRateLimiterMaxInFlight(t *testing.T) {
+	restoreStubs(t)
+	var inFlight, maxInFlight int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			max := atomic.LoadInt32(&maxInFlight)
+			if n <= max || atomic.CompareAndSwapInt32(&maxInFlight, max, n) {
+				break
+			}
+		}
+		time.Sleep(time.Duration(5) * time.Millisecond)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	limiter := NewRateLimiter(RateLimit{MaxInFlight: 2})
+	clients := []*Client{
+		NewClient(WithHost(server.URL+"/"), WithRateLimiter(limiter)),
+		NewClient(WithHost(server.URL+"/"), WithRateLimiter(limiter)),
+	}
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(c *Client) {
+			defer wg.Done()
+			if _, err := c.Delete(uuid.New(), 0); err != nil {
+				t.Errorf("unexpected error (%v)", err)
+			}
+		}(clients[i%2])
+	}
+	wg.Wait()
+	if maxInFlight > 2 {
+		t.Errorf("expected at most 2 requests in flight across clients, got %d", maxInFlight)
+	}
+}
+
+func TestRateLimiterHoldsSlotUntilBodyClosed(t *testing.T) {
+	restoreStubs(t)
+	doRequest = func(c *Client, req *http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader("{}"))}, nil
+	}
+	limiter := NewRateLimiter(RateLimit{MaxInFlight: 1})
+	c := NewClient(WithRateLimiter(limiter))
+	req, _ := http.NewRequest(http.MethodGet, "http://localhost/v1/organisation/accounts", nil)
+	response, err := doAttempt(c, req, 1)
+	if err != nil {
+		t.Fatalf("unexpected error (%v)", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(10)*time.Millisecond)
+	defer cancel()
+	if _, err := limiter.acquire(ctx); err == nil {
+		t.Fatal("expected the slot to be held while the response body is open")
+	}
+	response.Body.Close()
+	response.Body.Close()
+	release, err := limiter.acquire(context.Background())
+	if err != nil {
+		t.Fatalf("expected the slot to be released once the response body is closed, got (%v)", err)
+	}
+	release()
+	if len(limiter.slots) != 0 {
+		t.Errorf("expected the slot to be released once, got %d held", len(limiter.slots))
+	}
+}
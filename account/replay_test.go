@@ -0,0 +1,105 @@
+package account_test
+
+import (
+	"errors"
+	"flag"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/edihoxhalli/Form3-exercise/account"
+	"github.com/edihoxhalli/Form3-exercise/account/accounttest"
+	"github.com/google/uuid"
+)
+
+var record = flag.Bool("record", false,
+	"record the golden cassettes of testdata/cassettes against the accountapi at ACCOUNT_API_HOST, "+
+		"e.g. ACCOUNT_API_HOST=http://localhost:8080/ after docker-compose up accountapi")
+
+// cassetteClient returns a Client replaying the golden cassette with the given name,
+// or recording it when the -record flag is set.
+func cassetteClient(t *testing.T, name string) *account.Client {
+	path := filepath.Join("testdata", "cassettes", name+".json")
+	if !*record {
+		cassette, err := accounttest.LoadCassette(path)
+		if errors.Is(err, fs.ErrNotExist) {
+			t.Skipf("no cassette at %s, record it against the accountapi with -record", path)
+		}
+		if err != nil {
+			t.Fatalf("unable to load cassette (%v), run the tests with -record to create it", err)
+		}
+		replayer := accounttest.NewReplayer(cassette)
+		t.Cleanup(func() {
+			if unused := replayer.Unused(); len(unused) > 0 {
+				t.Errorf("expected every recorded interaction to be replayed, (%d) unused", len(unused))
+			}
+		})
+		return account.NewClient(account.WithHttpClient(&http.Client{Transport: replayer}))
+	}
+
+	// Golden cassettes are recorded against the real accountapi only,
+	// so that replaying them checks the Client against its actual responses.
+	host := os.Getenv("ACCOUNT_API_HOST")
+	if host == "" {
+		t.Fatalf("recording cassettes requires ACCOUNT_API_HOST to point at a running accountapi")
+	}
+	recorder := &accounttest.Recorder{}
+	t.Cleanup(func() {
+		if err := recorder.Save(path); err != nil {
+			t.Errorf("unable to save cassette (%v)", err)
+		}
+	})
+	return account.NewClient(account.WithHost(host), account.WithHttpClient(&http.Client{Transport: recorder}))
+}
+
+func TestReplayLifecycle(t *testing.T) {
+	t.Parallel()
+	client := cassetteClient(t, "lifecycle")
+	acc := testAccount("0d209d7f-d07a-4542-947f-5885fddddae2")
+	id := uuid.MustParse(acc.Data.ID)
+
+	created, err := client.Create(acc)
+	if err != nil || created.StatusCode != http.StatusCreated {
+		t.Fatalf("expected created account, got (%+v) and error (%v)", created, err)
+	}
+	fetched, err := client.Fetch(id)
+	if err != nil || fetched.ResponseBody.Data.Attributes.BankID != "400300" {
+		t.Fatalf("expected fetched account, got (%+v) and error (%v)", fetched, err)
+	}
+	list, err := client.List(account.ListOptions{PageSize: 10, Filter: account.ListFilter{BankID: "400300"}})
+	if err != nil || len(list.ResponseBody.Data) != 1 {
+		t.Fatalf("expected one listed account, got (%+v) and error (%v)", list, err)
+	}
+	if _, err := client.Delete(id, 0); err != nil {
+		t.Fatalf("expected nil error on delete, got (%v)", err)
+	}
+}
+
+func TestReplayErrors(t *testing.T) {
+	t.Parallel()
+	client := cassetteClient(t, "errors")
+	acc := testAccount("7c1f6a43-5d0d-4a3c-9d8f-2c1b3f0e9a11")
+	id := uuid.MustParse(acc.Data.ID)
+
+	_, err := client.Fetch(id)
+	if !errors.Is(err, account.ErrNotFound) {
+		t.Errorf("expected not found error, got (%v)", err)
+	}
+	if _, err := client.Create(acc); err != nil {
+		t.Fatalf("expected nil error on create, got (%v)", err)
+	}
+	_, err = client.Create(acc)
+	if !errors.Is(err, account.ErrConflict) {
+		t.Errorf("expected conflict error on duplicate create, got (%v)", err)
+	}
+	_, err = client.Delete(id, 3)
+	var conflictErr *account.VersionConflictError
+	if !errors.As(err, &conflictErr) {
+		t.Errorf("expected version conflict error, got (%v)", err)
+	}
+	if _, err := client.Delete(id, 0); err != nil {
+		t.Errorf("expected nil error on delete, got (%v)", err)
+	}
+}
@@ -0,0 +1,223 @@
+package account
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy configures how a Client retries failed requests.
+// Only idempotent operations (Fetch, List, Delete) are retried, unless
+// RetryCreate is set or Create is sent with an idempotency key.
+// Update is never retried, since its version check already protects
+// against concurrent modifications.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first one.
+	// A value lower than 2 disables retries.
+	MaxAttempts int
+	// BaseDelay is the delay before the first retry. It doubles on every further retry.
+	BaseDelay time.Duration
+	// MaxDelay caps the delay between two attempts, including the one requested
+	// by a Retry-After response header. Zero means no cap.
+	MaxDelay time.Duration
+	// Jitter is the fraction (between 0 and 1) of every delay which is randomised,
+	// so that concurrent clients do not retry in lockstep.
+	Jitter float64
+	// RetryableStatusCodes lists the response status codes which trigger a retry.
+	// Transport errors are always retried, unless the context is done or the circuit is open.
+	RetryableStatusCodes []int
+	// RetryCreate lets Create requests be retried as well.
+	// It should only be set when the API deduplicates Create requests,
+	// otherwise a retry may produce a 409 Conflict. Create requests sent with an
+	// idempotency key are retried regardless, see WithIdempotentCreate.
+	RetryCreate bool
+}
+
+// DefaultRetryPolicy returns a RetryPolicy with 3 attempts, an exponential
+// backoff from 100ms up to 2s with 20% jitter, retrying on 429, 502, 503 and 504.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: 3,
+		BaseDelay:   time.Duration(100) * time.Millisecond,
+		MaxDelay:    time.Duration(2) * time.Second,
+		Jitter:      0.2,
+		RetryableStatusCodes: []int{
+			http.StatusTooManyRequests,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout,
+		},
+	}
+}
+
+// WithRetryPolicy sets the RetryPolicy of the Client.
+// By default a Client does not retry.
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(c *Client) {
+		c.retryPolicy = policy
+	}
+}
+
+var (
+	randFloat = rand.Float64
+	sleep     = sleepContext
+	timeNow   = time.Now
+)
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return &RequestCanceledError{ctx.Err()}
+	case <-timer.C:
+		return nil
+	}
+}
+
+// doWithRetries executes req through doRequest as many times as the RetryPolicy of c allows.
+// The request body is rewound through req.GetBody before every retry.
+var doWithRetries = func(c *Client, req *http.Request) (*http.Response, error) {
+	ensureRequestID(c, req)
+	policy := c.retryPolicy
+	if policy.MaxAttempts < 2 || !policy.allows(req) {
+		return doAttempt(c, req, 1)
+	}
+	for attempt := 1; ; attempt++ {
+		attemptReq, err := rewindRequest(req, attempt)
+		if err != nil {
+			return nil, err
+		}
+		response, err := doAttempt(c, attemptReq, attempt)
+		if attempt >= policy.MaxAttempts || !policy.shouldRetry(response, err) {
+			return response, err
+		}
+		delay := policy.backoff(attempt)
+		if response != nil {
+			if retryAfter, ok := parseRetryAfter(response.Header.Get("Retry-After")); ok {
+				delay = retryAfter
+				if policy.MaxDelay > 0 && delay > policy.MaxDelay {
+					delay = policy.MaxDelay
+				}
+			}
+			io.Copy(ioutil.Discard, response.Body)
+			response.Body.Close()
+		}
+		if err := sleep(req.Context(), delay); err != nil {
+			return nil, err
+		}
+		c.metrics.Retried(operationName(req.Context()))
+	}
+}
+
+// doAttempt executes the given attempt of req once allowed by the CircuitBreaker and the RateLimiter,
+// tracing and logging it if enabled.
+func doAttempt(c *Client, req *http.Request, attempt int) (response *http.Response, err error) {
+	if c.circuitBreaker != nil {
+		ticket, allowErr := c.circuitBreaker.allow()
+		if allowErr != nil {
+			return nil, allowErr
+		}
+		defer func() { c.circuitBreaker.record(ticket, responseStatus(response), err) }()
+	}
+	if c.rateLimiter != nil {
+		release, acquireErr := c.rateLimiter.acquire(req.Context())
+		if acquireErr != nil {
+			return nil, acquireErr
+		}
+		// the in flight slot is held until the response body is closed
+		defer func() {
+			if response != nil && response.Body != nil {
+				response.Body = &releaseOnClose{ReadCloser: response.Body, release: release}
+			} else {
+				release()
+			}
+		}()
+	}
+	end := EndFunc(func(int, error) {})
+	if c.tracer != nil {
+		end = c.tracer.StartAttempt(req, attempt)
+	}
+	response, err = logAttempt(c, req, attempt)
+	end(responseStatus(response), err)
+	if c.rateLimiter != nil {
+		c.rateLimiter.observe(responseStatus(response))
+	}
+	return response, err
+}
+
+func rewindRequest(req *http.Request, attempt int) (*http.Request, error) {
+	if attempt == 1 || req.GetBody == nil {
+		return req, nil
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, err
+	}
+	attemptReq := req.Clone(req.Context())
+	attemptReq.Body = body
+	return attemptReq, nil
+}
+
+func (p RetryPolicy) allows(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodDelete:
+		return true
+	case http.MethodPost:
+		return p.RetryCreate || req.Header.Get(IdempotencyKeyHeader) != ""
+	default:
+		return false
+	}
+}
+
+func (p RetryPolicy) shouldRetry(response *http.Response, err error) bool {
+	if err != nil {
+		var canceledErr *RequestCanceledError
+		return !errors.As(err, &canceledErr) && !errors.Is(err, ErrCircuitOpen)
+	}
+	for _, code := range p.RetryableStatusCodes {
+		if response.StatusCode == code {
+			return true
+		}
+	}
+	return false
+}
+
+// backoff returns the delay before the retry following the given attempt.
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	delay := float64(p.BaseDelay) * math.Pow(2, float64(attempt-1))
+	if p.MaxDelay > 0 && delay > float64(p.MaxDelay) {
+		delay = float64(p.MaxDelay)
+	}
+	if p.Jitter > 0 {
+		delay -= delay * p.Jitter * randFloat()
+	}
+	return time.Duration(delay)
+}
+
+// parseRetryAfter parses a Retry-After header value, given either in seconds or as an http date.
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		delay := date.Sub(timeNow())
+		if delay < 0 {
+			delay = 0
+		}
+		return delay, true
+	}
+	return 0, false
+}
@@ -0,0 +1,259 @@
+package account
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func scriptedDoRequest(t *testing.T, script []func() (*http.Response, error), bodies *[]string) func(c *Client, req *http.Request) (*http.Response, error) {
+	call := 0
+	return func(c *Client, req *http.Request) (*http.Response, error) {
+		if call >= len(script) {
+			t.Fatalf("unexpected attempt %d", call+1)
+		}
+		if req.Body != nil {
+			body, _ := ioutil.ReadAll(req.Body)
+			*bodies = append(*bodies, string(body))
+		}
+		call++
+		return script[call-1]()
+	}
+}
+
+func statusResponse(statusCode int, header http.Header) func() (*http.Response, error) {
+	return func() (*http.Response, error) {
+		if header == nil {
+			header = make(http.Header)
+		}
+		return &http.Response{
+			StatusCode: statusCode,
+			Status:     http.StatusText(statusCode),
+			Header:     header,
+			Body:       io.NopCloser(bytes.NewBufferString("")),
+		}, nil
+	}
+}
+
+func transportError(err error) func() (*http.Response, error) {
+	return func() (*http.Response, error) {
+		return nil, err
+	}
+}
+
+func TestDoWithRetries(t *testing.T) {
+	restoreStubs(t)
+	policy := RetryPolicy{
+		MaxAttempts:          3,
+		BaseDelay:            time.Duration(100) * time.Millisecond,
+		MaxDelay:             time.Duration(150) * time.Millisecond,
+		RetryableStatusCodes: []int{http.StatusTooManyRequests, http.StatusServiceUnavailable},
+	}
+	createPolicy := policy
+	createPolicy.RetryCreate = true
+	uncappedPolicy := policy
+	uncappedPolicy.MaxDelay = 0
+
+	subtests := []struct {
+		name          string
+		policy        RetryPolicy
+		method        string
+		body          string
+		script        []func() (*http.Response, error)
+		expStatusCode int
+		expErr        error
+		expDelays     []time.Duration
+		expBodies     []string
+	}{
+		{
+			name:          "No retry policy",
+			method:        http.MethodGet,
+			script:        []func() (*http.Response, error){statusResponse(http.StatusServiceUnavailable, nil)},
+			expStatusCode: http.StatusServiceUnavailable,
+		},
+		{
+			name:   "Fetch retried until success",
+			policy: policy,
+			method: http.MethodGet,
+			script: []func() (*http.Response, error){
+				statusResponse(http.StatusServiceUnavailable, nil),
+				transportError(errors.New("connection reset")),
+				statusResponse(http.StatusOK, nil),
+			},
+			expStatusCode: http.StatusOK,
+			expDelays:     []time.Duration{time.Duration(100) * time.Millisecond, time.Duration(150) * time.Millisecond},
+		},
+		{
+			name:   "Delete gives up after max attempts",
+			policy: policy,
+			method: http.MethodDelete,
+			script: []func() (*http.Response, error){
+				statusResponse(http.StatusServiceUnavailable, nil),
+				statusResponse(http.StatusServiceUnavailable, nil),
+				statusResponse(http.StatusServiceUnavailable, nil),
+			},
+			expStatusCode: http.StatusServiceUnavailable,
+			expDelays:     []time.Duration{time.Duration(100) * time.Millisecond, time.Duration(150) * time.Millisecond},
+		},
+		{
+			name:   "Retry-After is honoured",
+			policy: uncappedPolicy,
+			method: http.MethodGet,
+			script: []func() (*http.Response, error){
+				statusResponse(http.StatusTooManyRequests, http.Header{"Retry-After": []string{"2"}}),
+				statusResponse(http.StatusOK, nil),
+			},
+			expStatusCode: http.StatusOK,
+			expDelays:     []time.Duration{time.Duration(2) * time.Second},
+		},
+		{
+			name:   "Retry-After is capped by MaxDelay",
+			policy: policy,
+			method: http.MethodGet,
+			script: []func() (*http.Response, error){
+				statusResponse(http.StatusTooManyRequests, http.Header{"Retry-After": []string{"3600"}}),
+				statusResponse(http.StatusOK, nil),
+			},
+			expStatusCode: http.StatusOK,
+			expDelays:     []time.Duration{time.Duration(150) * time.Millisecond},
+		},
+		{
+			name:          "Non retryable status code",
+			policy:        policy,
+			method:        http.MethodGet,
+			script:        []func() (*http.Response, error){statusResponse(http.StatusInternalServerError, nil)},
+			expStatusCode: http.StatusInternalServerError,
+		},
+		{
+			name:          "Create not retried by default",
+			policy:        policy,
+			method:        http.MethodPost,
+			body:          "account",
+			script:        []func() (*http.Response, error){statusResponse(http.StatusServiceUnavailable, nil)},
+			expStatusCode: http.StatusServiceUnavailable,
+			expBodies:     []string{"account"},
+		},
+		{
+			name:   "Create retried with rewound body when opted in",
+			policy: createPolicy,
+			method: http.MethodPost,
+			body:   "account",
+			script: []func() (*http.Response, error){
+				statusResponse(http.StatusServiceUnavailable, nil),
+				statusResponse(http.StatusCreated, nil),
+			},
+			expStatusCode: http.StatusCreated,
+			expDelays:     []time.Duration{time.Duration(100) * time.Millisecond},
+			expBodies:     []string{"account", "account"},
+		},
+		{
+			name:          "Update never retried",
+			policy:        createPolicy,
+			method:        http.MethodPatch,
+			body:          "account",
+			script:        []func() (*http.Response, error){statusResponse(http.StatusServiceUnavailable, nil)},
+			expStatusCode: http.StatusServiceUnavailable,
+			expBodies:     []string{"account"},
+		},
+		{
+			name:   "Canceled request not retried",
+			policy: policy,
+			method: http.MethodGet,
+			script: []func() (*http.Response, error){
+				transportError(&RequestCanceledError{context.Canceled}),
+			},
+			expErr: &RequestCanceledError{context.Canceled},
+		},
+	}
+	for _, subtest := range subtests {
+		t.Run(subtest.name, func(t *testing.T) {
+			var delays []time.Duration
+			var bodies []string
+			doRequest = scriptedDoRequest(t, subtest.script, &bodies)
+			sleep = func(ctx context.Context, d time.Duration) error {
+				delays = append(delays, d)
+				return nil
+			}
+			req, _ := http.NewRequest(subtest.method, "http://localhost:8080/v1/organisation/accounts", nil)
+			if subtest.body != "" {
+				setJSONBody(req, []byte(subtest.body))
+			}
+
+			result, err := doWithRetries(NewClient(WithRetryPolicy(subtest.policy)), req)
+			if subtest.expErr != nil {
+				if err == nil || err.Error() != subtest.expErr.Error() {
+					t.Errorf("expected error (%v), got (%v)", subtest.expErr, err)
+				}
+			} else if err != nil {
+				t.Errorf("expected nil error, got (%v)", err)
+			} else if result.StatusCode != subtest.expStatusCode {
+				t.Errorf("expected status code (%d), got (%d)", subtest.expStatusCode, result.StatusCode)
+			}
+			if !reflect.DeepEqual(delays, subtest.expDelays) {
+				t.Errorf("expected delays (%v), got (%v)", subtest.expDelays, delays)
+			}
+			if !reflect.DeepEqual(bodies, subtest.expBodies) {
+				t.Errorf("expected bodies (%v), got (%v)", subtest.expBodies, bodies)
+			}
+		})
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	restoreStubs(t)
+	randFloat = func() float64 { return 0.5 }
+	policy := RetryPolicy{
+		BaseDelay: time.Duration(100) * time.Millisecond,
+		MaxDelay:  time.Duration(1) * time.Second,
+		Jitter:    0.2,
+	}
+	subtests := []struct {
+		attempt  int
+		expDelay time.Duration
+	}{
+		{1, time.Duration(90) * time.Millisecond},
+		{2, time.Duration(180) * time.Millisecond},
+		{3, time.Duration(360) * time.Millisecond},
+		{5, time.Duration(900) * time.Millisecond},
+	}
+	for _, subtest := range subtests {
+		result := policy.backoff(subtest.attempt)
+		if result != subtest.expDelay {
+			t.Errorf("expected delay (%v) for attempt %d, got (%v)", subtest.expDelay, subtest.attempt, result)
+		}
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	restoreStubs(t)
+	now := time.Date(2022, time.March, 1, 10, 0, 0, 0, time.UTC)
+	timeNow = func() time.Time { return now }
+
+	subtests := []struct {
+		name     string
+		value    string
+		expDelay time.Duration
+		expOk    bool
+	}{
+		{"Empty", "", 0, false},
+		{"Seconds", "3", time.Duration(3) * time.Second, true},
+		{"Negative seconds", "-3", 0, false},
+		{"Http date", now.Add(time.Duration(5) * time.Second).Format(http.TimeFormat), time.Duration(5) * time.Second, true},
+		{"Http date in the past", now.Add(-time.Minute).Format(http.TimeFormat), 0, true},
+		{"Invalid", "soon", 0, false},
+	}
+	for _, subtest := range subtests {
+		t.Run(subtest.name, func(t *testing.T) {
+			delay, ok := parseRetryAfter(subtest.value)
+			if delay != subtest.expDelay || ok != subtest.expOk {
+				t.Errorf("expected (%v, %v), got (%v, %v)", subtest.expDelay, subtest.expOk, delay, ok)
+			}
+		})
+	}
+}
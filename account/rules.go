@@ -0,0 +1,155 @@
+package account
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/edihoxhalli/Form3-exercise/account/iban"
+)
+
+// CountryRule validates the country specific combination of BankID, BankIDCode,
+// Bic, AccountNumber and Iban of an Account.
+// It returns one FieldViolation per invalid field, or nil if the attributes are valid.
+type CountryRule interface {
+	Validate(attrs AccountAttributes) []FieldViolation
+}
+
+// CountryRuleFunc is an adapter allowing the use of ordinary functions as CountryRule.
+type CountryRuleFunc func(attrs AccountAttributes) []FieldViolation
+
+func (f CountryRuleFunc) Validate(attrs AccountAttributes) []FieldViolation {
+	return f(attrs)
+}
+
+// BankRule is a declarative CountryRule, describing the bank id and account number
+// formats accepted by the form3 API for a country.
+// Fields left empty are not checked. A given valid Iban must belong to the country
+// of the Account, and its bank, branch and account parts must match BankID, Bic
+// and AccountNumber, unless IbanNotSupported is set.
+type BankRule struct {
+	// BankIDCode is the only accepted BankIDCode, when set.
+	BankIDCode BankIDCode
+	// BankIDRequired makes BankID mandatory.
+	BankIDRequired bool
+	// BankID is the format of BankID, when given.
+	BankID *regexp.Regexp
+	// BicRequired makes Bic mandatory.
+	BicRequired bool
+	// AccountNumber is the format of AccountNumber, when given.
+	AccountNumber *regexp.Regexp
+	// IbanNotSupported rejects any given Iban.
+	IbanNotSupported bool
+}
+
+func (r BankRule) Validate(attrs AccountAttributes) []FieldViolation {
+	var v validator
+	if r.BankIDRequired && attrs.BankID == "" {
+		v.add("data.attributes.bank_id", "is required")
+	}
+	if r.BankID != nil && attrs.BankID != "" && !r.BankID.MatchString(attrs.BankID) {
+		v.add("data.attributes.bank_id", fmt.Sprintf("must match %s", r.BankID))
+	}
+	if r.BankIDCode != "" && (attrs.BankID != "" || attrs.BankIDCode != "") && attrs.BankIDCode != r.BankIDCode {
+		v.add("data.attributes.bank_id_code", fmt.Sprintf("must be %q", r.BankIDCode))
+	}
+	if r.BicRequired && attrs.Bic == "" {
+		v.add("data.attributes.bic", "is required")
+	}
+	if r.AccountNumber != nil && attrs.AccountNumber != "" && !r.AccountNumber.MatchString(attrs.AccountNumber) {
+		v.add("data.attributes.account_number", fmt.Sprintf("must match %s", r.AccountNumber))
+	}
+	if r.IbanNotSupported && attrs.Iban != "" {
+		v.add("data.attributes.iban", "is not supported for this country")
+	} else if attrs.Iban != "" {
+		validateIbanParts(attrs, &v)
+	}
+	return v.violations
+}
+
+// validateIbanParts checks that the parts of the Iban of attrs match its other bank details.
+// Invalid IBANs are left to the generic checks of AccountAttributes.
+func validateIbanParts(attrs AccountAttributes, v *validator) {
+	parsed, err := iban.Parse(attrs.Iban)
+	if err != nil || attrs.Country == nil {
+		return
+	}
+	if parsed.CountryCode != *attrs.Country {
+		v.add("data.attributes.iban", fmt.Sprintf("must have country code %q", *attrs.Country))
+		return
+	}
+	// BankID may hold more than the bank and branch codes of the IBAN (e.g. national check digits),
+	// or less of them when it is missing, so only a divergence between the two is reported.
+	if bankCode, branchCode, err := ibanBankCodes(attrs); err == nil {
+		expected, actual := bankCode+branchCode, parsed.BankCode+parsed.BranchCode
+		if !strings.HasPrefix(expected, actual) && !strings.HasPrefix(actual, expected) {
+			v.add("data.attributes.iban", "bank and branch codes must match bank_id and bic")
+		}
+	}
+	if attrs.AccountNumber != "" && parsed.AccountNumber != "" {
+		padding := len(parsed.AccountNumber) - len(attrs.AccountNumber)
+		if padding < 0 || strings.Repeat("0", padding)+attrs.AccountNumber != parsed.AccountNumber {
+			v.add("data.attributes.iban", "account number must match account_number")
+		}
+	}
+}
+
+var (
+	countryRulesMu sync.RWMutex
+	countryRules   = map[string]CountryRule{
+		"AU": BankRule{BankIDCode: BankIDCodeAUBSB, BankID: regexp.MustCompile(`^[0-9]{6}$`), BicRequired: true,
+			AccountNumber: regexp.MustCompile(`^[0-9]{6,10}$`), IbanNotSupported: true},
+		"BE": BankRule{BankIDCode: BankIDCodeBE, BankIDRequired: true, BankID: regexp.MustCompile(`^[0-9]{3}$`),
+			AccountNumber: regexp.MustCompile(`^[0-9]{7}$`)},
+		"CA": BankRule{BankIDCode: BankIDCodeCACPA, BankID: regexp.MustCompile(`^0[0-9]{8}$`), BicRequired: true,
+			AccountNumber: regexp.MustCompile(`^[0-9]{7,12}$`), IbanNotSupported: true},
+		"CH": BankRule{BankIDCode: BankIDCodeCHBCC, BankIDRequired: true, BankID: regexp.MustCompile(`^[0-9]{5}$`),
+			AccountNumber: regexp.MustCompile(`^[0-9A-Z]{12}$`)},
+		"DE": BankRule{BankIDCode: BankIDCodeDEBLZ, BankIDRequired: true, BankID: regexp.MustCompile(`^[0-9]{8}$`),
+			AccountNumber: regexp.MustCompile(`^[0-9]{7,10}$`)},
+		"ES": BankRule{BankIDCode: BankIDCodeESNCC, BankIDRequired: true, BankID: regexp.MustCompile(`^[0-9]{8,9}$`),
+			AccountNumber: regexp.MustCompile(`^[0-9]{10}$`)},
+		"FR": BankRule{BankIDCode: BankIDCodeFR, BankIDRequired: true, BankID: regexp.MustCompile(`^[0-9A-Z]{10}$`),
+			AccountNumber: regexp.MustCompile(`^[0-9A-Z]{10,11}$`)},
+		"GB": BankRule{BankIDCode: BankIDCodeGBDSC, BankIDRequired: true, BankID: regexp.MustCompile(`^[0-9]{6}$`), BicRequired: true,
+			AccountNumber: regexp.MustCompile(`^[0-9]{8}$`)},
+		"GR": BankRule{BankIDCode: BankIDCodeGRBIC, BankIDRequired: true, BankID: regexp.MustCompile(`^[0-9]{7}$`),
+			AccountNumber: regexp.MustCompile(`^[0-9]{16}$`)},
+		"HK": BankRule{BankIDCode: BankIDCodeHKNCC, BankID: regexp.MustCompile(`^[0-9]{3}$`), BicRequired: true,
+			AccountNumber: regexp.MustCompile(`^[0-9]{9,12}$`), IbanNotSupported: true},
+		"IT": BankRule{BankIDCode: BankIDCodeITNCC, BankIDRequired: true, BankID: regexp.MustCompile(`^[0-9A-Z]{10,11}$`),
+			AccountNumber: regexp.MustCompile(`^[0-9A-Z]{12}$`)},
+		"LU": BankRule{BankIDCode: BankIDCodeLULUX, BankIDRequired: true, BankID: regexp.MustCompile(`^[0-9]{3}$`),
+			AccountNumber: regexp.MustCompile(`^[0-9A-Z]{13}$`)},
+		"NL": BankRule{BicRequired: true, AccountNumber: regexp.MustCompile(`^[0-9]{10}$`)},
+		"PL": BankRule{BankIDCode: BankIDCodePLKNR, BankIDRequired: true, BankID: regexp.MustCompile(`^[0-9]{8}$`),
+			AccountNumber: regexp.MustCompile(`^[0-9]{16}$`)},
+		"PT": BankRule{BankIDCode: BankIDCodePTNCC, BankIDRequired: true, BankID: regexp.MustCompile(`^[0-9]{8}$`),
+			AccountNumber: regexp.MustCompile(`^[0-9]{11}$`)},
+		"US": BankRule{BankIDCode: BankIDCodeUSABA, BankIDRequired: true, BankID: regexp.MustCompile(`^[0-9]{9}$`), BicRequired: true,
+			AccountNumber: regexp.MustCompile(`^[0-9]{6,17}$`), IbanNotSupported: true},
+	}
+)
+
+// RegisterCountryRule registers the CountryRule used by Validate for Accounts
+// of the given ISO 3166-1 alpha-2 country, replacing any existing rule,
+// including the built-in ones. A nil rule removes the rule of the country.
+// It is safe for concurrent use.
+func RegisterCountryRule(country string, rule CountryRule) {
+	countryRulesMu.Lock()
+	defer countryRulesMu.Unlock()
+	if rule == nil {
+		delete(countryRules, country)
+		return
+	}
+	countryRules[country] = rule
+}
+
+// LookupCountryRule returns the CountryRule registered for the given country, if any.
+func LookupCountryRule(country string) (CountryRule, bool) {
+	countryRulesMu.RLock()
+	defer countryRulesMu.RUnlock()
+	rule, ok := countryRules[country]
+	return rule, ok
+}
@@ -0,0 +1,98 @@
+package account
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestBuiltInCountryRules(t *testing.T) {
+	subtests := []struct {
+		name          string
+		attrs         AccountAttributes
+		expViolations []FieldViolation
+	}{
+		{
+			name: "Valid GB account",
+			attrs: AccountAttributes{Country: newStringPointer("GB"), BankID: "400300", BankIDCode: "GBDSC",
+				Bic: "NWBKGB22", AccountNumber: "41426819"},
+		},
+		{
+			name:  "Invalid GB account",
+			attrs: AccountAttributes{Country: newStringPointer("GB"), BankID: "4003", BankIDCode: "GBSC", AccountNumber: "1231555"},
+			expViolations: []FieldViolation{
+				{"data.attributes.bank_id", "must match ^[0-9]{6}$"},
+				{"data.attributes.bank_id_code", `must be "GBDSC"`},
+				{"data.attributes.bic", "is required"},
+				{"data.attributes.account_number", "must match ^[0-9]{8}$"},
+			},
+		},
+		{
+			name:          "FR account without bank id",
+			attrs:         AccountAttributes{Country: newStringPointer("FR")},
+			expViolations: []FieldViolation{{"data.attributes.bank_id", "is required"}},
+		},
+		{
+			name: "GB account with matching IBAN",
+			attrs: AccountAttributes{Country: newStringPointer("GB"), BankID: "601613", BankIDCode: "GBDSC",
+				Bic: "NWBKGB22", AccountNumber: "31926819", Iban: "GB29NWBK60161331926819"},
+		},
+		{
+			name: "GB account with mismatching IBAN",
+			attrs: AccountAttributes{Country: newStringPointer("GB"), BankID: "400300", BankIDCode: "GBDSC",
+				Bic: "NWBKGB22", AccountNumber: "41426819", Iban: "GB29NWBK60161331926819"},
+			expViolations: []FieldViolation{
+				{"data.attributes.iban", "bank and branch codes must match bank_id and bic"},
+				{"data.attributes.iban", "account number must match account_number"},
+			},
+		},
+		{
+			name: "DE account with padded account number",
+			attrs: AccountAttributes{Country: newStringPointer("DE"), BankID: "37040044", BankIDCode: "DEBLZ",
+				AccountNumber: "532013000", Iban: "DE89370400440532013000"},
+		},
+		{
+			name: "DE account with IBAN of another country",
+			attrs: AccountAttributes{Country: newStringPointer("DE"), BankID: "37040044", BankIDCode: "DEBLZ",
+				AccountNumber: "532013000", Iban: "GB29NWBK60161331926819"},
+			expViolations: []FieldViolation{{"data.attributes.iban", `must have country code "DE"`}},
+		},
+		{
+			name: "AU account with IBAN",
+			attrs: AccountAttributes{Country: newStringPointer("AU"), BankID: "123456", BankIDCode: "AUBSB",
+				Bic: "NAIAAU33", Iban: "AU00123"},
+			expViolations: []FieldViolation{{"data.attributes.iban", "is not supported for this country"}},
+		},
+	}
+	for _, subtest := range subtests {
+		t.Run(subtest.name, func(t *testing.T) {
+			rule, ok := LookupCountryRule(*subtest.attrs.Country)
+			if !ok {
+				t.Fatalf("expected a built-in rule for %s", *subtest.attrs.Country)
+			}
+			result := rule.Validate(subtest.attrs)
+			if !reflect.DeepEqual(result, subtest.expViolations) {
+				t.Errorf("expected violations (%+v), got (%+v)", subtest.expViolations, result)
+			}
+		})
+	}
+}
+
+func TestRegisterCountryRule(t *testing.T) {
+	previous, _ := LookupCountryRule("GB")
+	defer RegisterCountryRule("GB", previous)
+
+	violation := FieldViolation{"data.attributes.bank_id", "is blocked"}
+	RegisterCountryRule("GB", CountryRuleFunc(func(attrs AccountAttributes) []FieldViolation {
+		return []FieldViolation{violation}
+	}))
+	err := validAccount().Validate()
+	validationErr, ok := err.(*ValidationError)
+	if !ok || !reflect.DeepEqual(validationErr.Violations, []FieldViolation{violation}) {
+		t.Errorf("expected registered rule violation, got (%v)", err)
+	}
+
+	RegisterCountryRule("GB", nil)
+	if _, ok := LookupCountryRule("GB"); ok {
+		t.Errorf("expected GB rule to be removed")
+	}
+}
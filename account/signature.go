@@ -0,0 +1,150 @@
+package account
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+)
+
+const (
+	signature_unsupported_key_formatting = "HTTP SIGNATURE UNSUPPORTED PRIVATE KEY TYPE %T, EXPECTED RSA OR ECDSA"
+	signature_invalid_pem_formatting     = "HTTP SIGNATURE PRIVATE KEY IS NOT A VALID PEM BLOCK"
+	signature_missing_header_formatting  = "HTTP SIGNATURE HEADER %q TO SIGN IS MISSING FROM THE REQUEST"
+)
+
+// HTTPSignatureAuth is an Authenticator signing requests according to the
+// draft-cavage HTTP Signatures specification, as required by form3 production environments.
+// The signature is sent in the Authorization header:
+//
+//	Authorization: Signature keyId="...",algorithm="rsa-sha256",headers="(request-target) host date digest",signature="..."
+type HTTPSignatureAuth struct {
+	keyID     string
+	key       crypto.Signer
+	algorithm string
+	headers   []string
+}
+
+// NewHTTPSignatureAuth returns an HTTPSignatureAuth signing with the given key id
+// and RSA (rsa-sha256) or ECDSA (ecdsa-sha256) private key.
+// headers lists the headers to sign; it defaults to (request-target), host, date and,
+// for requests with a body, digest.
+func NewHTTPSignatureAuth(keyID string, key crypto.Signer, headers ...string) (*HTTPSignatureAuth, error) {
+	auth := &HTTPSignatureAuth{keyID: keyID, key: key, headers: headers}
+	switch key.(type) {
+	case *rsa.PrivateKey:
+		auth.algorithm = "rsa-sha256"
+	case *ecdsa.PrivateKey:
+		auth.algorithm = "ecdsa-sha256"
+	default:
+		return nil, fmt.Errorf(signature_unsupported_key_formatting, key)
+	}
+	return auth, nil
+}
+
+// NewHTTPSignatureAuthFromPEM is like NewHTTPSignatureAuth, parsing the private key
+// from a PEM encoded PKCS #1, PKCS #8 or SEC 1 block.
+func NewHTTPSignatureAuthFromPEM(keyID string, pemKey []byte, headers ...string) (*HTTPSignatureAuth, error) {
+	block, _ := pem.Decode(pemKey)
+	if block == nil {
+		return nil, errors.New(signature_invalid_pem_formatting)
+	}
+	var key interface{}
+	var err error
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		key, err = x509.ParseECPrivateKey(block.Bytes)
+	default:
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	}
+	if err != nil {
+		return nil, err
+	}
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf(signature_unsupported_key_formatting, key)
+	}
+	return NewHTTPSignatureAuth(keyID, signer, headers...)
+}
+
+// Authenticate sets the Date header to the current time in the HTTP date format,
+// replacing any Date set when the request was built or by a previous attempt,
+// adds the Digest header when missing, and signs req.
+func (a *HTTPSignatureAuth) Authenticate(req *http.Request) error {
+	req.Header.Set("Date", timeNow().UTC().Format(http.TimeFormat))
+	if req.Header.Get("Digest") == "" && req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return err
+		}
+		content, err := ioutil.ReadAll(body)
+		body.Close()
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Digest", digestHeader(content))
+	}
+
+	headers := a.headers
+	if len(headers) == 0 {
+		headers = []string{"(request-target)", "host", "date"}
+		if req.Header.Get("Digest") != "" {
+			headers = append(headers, "digest")
+		}
+	}
+	signingString, err := signingString(req, headers)
+	if err != nil {
+		return err
+	}
+	hashed := sha256.Sum256([]byte(signingString))
+	signature, err := a.key.Sign(rand.Reader, hashed[:], crypto.SHA256)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", fmt.Sprintf(`Signature keyId="%s",algorithm="%s",headers="%s",signature="%s"`,
+		a.keyID, a.algorithm, strings.Join(headers, " "), base64.StdEncoding.EncodeToString(signature)))
+	return nil
+}
+
+// signingString builds the string to sign out of the given headers of req.
+func signingString(req *http.Request, headers []string) (string, error) {
+	lines := make([]string, 0, len(headers))
+	for _, h := range headers {
+		h = strings.ToLower(h)
+		var value string
+		switch h {
+		case "(request-target)":
+			value = strings.ToLower(req.Method) + " " + req.URL.RequestURI()
+		case "host":
+			value = req.Host
+			if value == "" {
+				value = req.URL.Host
+			}
+		default:
+			values := req.Header.Values(h)
+			if len(values) == 0 {
+				return "", fmt.Errorf(signature_missing_header_formatting, h)
+			}
+			value = strings.Join(values, ", ")
+		}
+		lines = append(lines, h+": "+value)
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+// digestHeader returns the value of the Digest header of a request with the given body.
+func digestHeader(body []byte) string {
+	sum := sha256.Sum256(body)
+	return "SHA-256=" + base64.StdEncoding.EncodeToString(sum[:])
+}
@@ -0,0 +1,190 @@
+package account
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var authorizationPattern = regexp.MustCompile(`^Signature keyId="([^"]*)",algorithm="([^"]*)",headers="([^"]*)",signature="([^"]*)"$`)
+
+func TestHTTPSignatureAuth(t *testing.T) {
+	rsaKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	ecdsaKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+
+	subtests := []struct {
+		name         string
+		key          crypto.Signer
+		body         string
+		expAlgorithm string
+		expHeaders   string
+		verify       func(hashed, signature []byte) bool
+	}{
+		{
+			name:         "RSA signed GET",
+			key:          rsaKey,
+			expAlgorithm: "rsa-sha256",
+			expHeaders:   "(request-target) host date",
+			verify: func(hashed, signature []byte) bool {
+				return rsa.VerifyPKCS1v15(&rsaKey.PublicKey, crypto.SHA256, hashed, signature) == nil
+			},
+		},
+		{
+			name:         "ECDSA signed POST with digest",
+			key:          ecdsaKey,
+			body:         `{"data":{}}`,
+			expAlgorithm: "ecdsa-sha256",
+			expHeaders:   "(request-target) host date digest",
+			verify: func(hashed, signature []byte) bool {
+				return ecdsa.VerifyASN1(&ecdsaKey.PublicKey, hashed, signature)
+			},
+		},
+	}
+	for _, subtest := range subtests {
+		t.Run(subtest.name, func(t *testing.T) {
+			auth, err := NewHTTPSignatureAuth("key-id", subtest.key)
+			if err != nil {
+				t.Fatalf("unexpected error (%v)", err)
+			}
+			method := http.MethodGet
+			if subtest.body != "" {
+				method = http.MethodPost
+			}
+			req, _ := http.NewRequest(method, "http://localhost:8080/v1/organisation/accounts?version=0", nil)
+			if subtest.body != "" {
+				setJSONBody(req, []byte(subtest.body))
+			}
+			if err := auth.Authenticate(req); err != nil {
+				t.Fatalf("unexpected error (%v)", err)
+			}
+
+			parts := authorizationPattern.FindStringSubmatch(req.Header.Get("Authorization"))
+			if parts == nil {
+				t.Fatalf("unexpected Authorization header (%s)", req.Header.Get("Authorization"))
+			}
+			if parts[1] != "key-id" || parts[2] != subtest.expAlgorithm || parts[3] != subtest.expHeaders {
+				t.Errorf("expected key id, algorithm and headers (key-id, %s, %s), got (%s, %s, %s)",
+					subtest.expAlgorithm, subtest.expHeaders, parts[1], parts[2], parts[3])
+			}
+			signing, err := signingString(req, strings.Fields(parts[3]))
+			if err != nil {
+				t.Fatalf("unexpected error (%v)", err)
+			}
+			if !strings.HasPrefix(signing, "(request-target): "+strings.ToLower(method)+" /v1/organisation/accounts?version=0\nhost: localhost:8080\ndate: ") {
+				t.Errorf("unexpected signing string (%s)", signing)
+			}
+			signature, _ := base64.StdEncoding.DecodeString(parts[4])
+			hashed := sha256.Sum256([]byte(signing))
+			if !subtest.verify(hashed[:], signature) {
+				t.Errorf("signature does not verify")
+			}
+			if subtest.body != "" && req.Header.Get("Digest") != digestHeader([]byte(subtest.body)) {
+				t.Errorf("expected Digest (%s), got (%s)", digestHeader([]byte(subtest.body)), req.Header.Get("Digest"))
+			}
+		})
+	}
+}
+
+func TestHTTPSignatureAuthThroughClient(t *testing.T) {
+	restoreStubs(t)
+	now := time.Date(2022, time.March, 1, 10, 0, 0, 0, time.UTC)
+	timeNow = func() time.Time {
+		now = now.Add(time.Second)
+		return now
+	}
+	sleep = func(ctx context.Context, d time.Duration) error { return nil }
+	rsaKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	auth, _ := NewHTTPSignatureAuth("key-id", rsaKey)
+
+	var dates []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		dates = append(dates, strings.Join(r.Header.Values("Date"), ", "))
+		parts := authorizationPattern.FindStringSubmatch(r.Header.Get("Authorization"))
+		if parts == nil {
+			t.Errorf("unexpected Authorization header (%s)", r.Header.Get("Authorization"))
+		} else {
+			signing, err := signingString(r, strings.Fields(parts[3]))
+			signature, _ := base64.StdEncoding.DecodeString(parts[4])
+			hashed := sha256.Sum256([]byte(signing))
+			if err != nil || rsa.VerifyPKCS1v15(&rsaKey.PublicKey, crypto.SHA256, hashed[:], signature) != nil {
+				t.Errorf("signature does not verify (%v)", err)
+			}
+		}
+		if len(dates) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	c := NewClient(WithHost(server.URL+"/"), WithAuthenticator(auth), WithRetryPolicy(DefaultRetryPolicy()))
+	if _, err := c.Delete(uuid.New(), 0); err != nil {
+		t.Fatalf("unexpected error (%v)", err)
+	}
+	expDates := []string{"Tue, 01 Mar 2022 10:00:01 GMT", "Tue, 01 Mar 2022 10:00:02 GMT"}
+	if !reflect.DeepEqual(dates, expDates) {
+		t.Errorf("expected one HTTP Date per attempt (%v), got (%v)", expDates, dates)
+	}
+}
+
+func TestHTTPSignatureAuthMissingHeader(t *testing.T) {
+	rsaKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	auth, _ := NewHTTPSignatureAuth("key-id", rsaKey, "(request-target)", "x-request-id")
+	req, _ := http.NewRequest(http.MethodGet, "http://localhost:8080/v1/organisation/accounts", nil)
+	if err := auth.Authenticate(req); err == nil {
+		t.Errorf("expected error for missing signed header")
+	}
+}
+
+func TestNewHTTPSignatureAuthFromPEM(t *testing.T) {
+	rsaKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	ecdsaKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	pkcs8, _ := x509.MarshalPKCS8PrivateKey(ecdsaKey)
+	sec1, _ := x509.MarshalECPrivateKey(ecdsaKey)
+
+	subtests := []struct {
+		name         string
+		pem          []byte
+		expAlgorithm string
+		expErr       bool
+	}{
+		{"PKCS #1 RSA key", pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(rsaKey)}), "rsa-sha256", false},
+		{"SEC 1 ECDSA key", pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: sec1}), "ecdsa-sha256", false},
+		{"PKCS #8 ECDSA key", pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: pkcs8}), "ecdsa-sha256", false},
+		{"Not a PEM block", []byte("key"), "", true},
+	}
+	for _, subtest := range subtests {
+		t.Run(subtest.name, func(t *testing.T) {
+			auth, err := NewHTTPSignatureAuthFromPEM("key-id", subtest.pem)
+			if subtest.expErr {
+				if err == nil {
+					t.Errorf("expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error (%v)", err)
+			}
+			if auth.algorithm != subtest.expAlgorithm {
+				t.Errorf("expected algorithm (%s), got (%s)", subtest.expAlgorithm, auth.algorithm)
+			}
+		})
+	}
+}
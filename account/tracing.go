@@ -0,0 +1,105 @@
+package account
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+)
+
+// Names of the operations reported to a Tracer.
+const (
+	OperationCreate = "create"
+	OperationFetch  = "fetch"
+	OperationUpdate = "update"
+	OperationDelete = "delete"
+	OperationList   = "list"
+)
+
+// Operation identifies an operation of a Client, as reported to a Tracer.
+// AccountID and OrganisationID are empty when not known before the call,
+// e.g. OrganisationID on Fetch.
+type Operation struct {
+	Name           string
+	AccountID      string
+	OrganisationID string
+}
+
+// EndFunc ends the instrumentation of an operation or attempt, with the status code
+// of its response, or 0 if there was none, and its error, if any.
+type EndFunc func(statusCode int, err error)
+
+// Tracer instruments the operations of a Client and every http attempt they make,
+// e.g. with spans. See package accountotel for an OpenTelemetry implementation.
+type Tracer interface {
+	// StartOperation is called when an operation starts. The returned context is used
+	// for the requests of the operation, and the returned EndFunc is called once it is over.
+	StartOperation(ctx context.Context, op Operation) (context.Context, EndFunc)
+	// StartAttempt is called before every attempt of a request, numbered from 1, with the
+	// context of its operation. It may set headers on req, e.g. to propagate the trace.
+	// The returned EndFunc is called once the response headers are received.
+	StartAttempt(req *http.Request, attempt int) EndFunc
+}
+
+// WithTracer sets the Tracer instrumenting the operations of the Client.
+// By default operations are not traced.
+func WithTracer(tracer Tracer) Option {
+	return func(c *Client) {
+		c.tracer = tracer
+	}
+}
+
+// startOperation starts the tracing and metrics of op. The returned EndFunc takes
+// the status code of the operation response, and picks the one of the error, if any.
+func (c *Client) startOperation(ctx context.Context, op Operation) (context.Context, EndFunc) {
+	start := time.Now()
+	c.metrics.OperationStarted(op.Name)
+	ctx = context.WithValue(ctx, operationKey{}, op)
+	end := EndFunc(func(int, error) {})
+	if c.tracer != nil {
+		ctx, end = c.tracer.StartOperation(ctx, op)
+	}
+	return ctx, func(statusCode int, err error) {
+		statusCode = operationStatus(statusCode, err)
+		c.metrics.OperationDone(op.Name, StatusClass(statusCode), time.Since(start))
+		end(statusCode, err)
+	}
+}
+
+func operationStatus(statusCode int, err error) int {
+	var apiErr *ApiError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode
+	}
+	return statusCode
+}
+
+func responseStatus(response *http.Response) int {
+	if response == nil {
+		return 0
+	}
+	return response.StatusCode
+}
+
+func (r *AccountApiResponse) statusCode() int {
+	if r == nil {
+		return 0
+	}
+	return r.StatusCode
+}
+
+func (r *AccountListApiResponse) statusCode() int {
+	if r == nil {
+		return 0
+	}
+	return r.StatusCode
+}
+
+// accountOperation returns the Operation of name on acc.
+func accountOperation(name string, acc Account) Operation {
+	op := Operation{Name: name}
+	if acc.Data != nil {
+		op.AccountID, op.OrganisationID = acc.Data.ID, acc.Data.OrganisationID
+	}
+	return op
+}
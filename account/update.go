@@ -0,0 +1,73 @@
+package account
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+)
+
+// Update enables to modify an Account record on the form3 API,
+// using the default Client.
+// See Client.Update for more information.
+func Update(acc Account) (*AccountApiResponse, error) {
+	return defaultClient().Update(acc)
+}
+
+// UpdateContext is like Update but carries a context.
+// See Client.UpdateContext for more information.
+func UpdateContext(ctx context.Context, acc Account) (*AccountApiResponse, error) {
+	return defaultClient().UpdateContext(ctx, acc)
+}
+
+// Update enables to modify an Account record on the form3 API.
+// It takes as parameter a partial Account, whose Data must hold the ID
+// and the current Version of the record, along with the Attributes to change only.
+// It returns the updated Account wrapped inside the AccountApiResponse pointer var,
+// along with the Status and Status Code response details.
+// In case the Version is not the current one, it returns nil, along with a *VersionConflictError,
+// so that the caller can fetch the Account again and retry.
+// In case any other error occurs while attempting to update the Account,
+// it returns nil, along with the error.
+func (c *Client) Update(acc Account) (*AccountApiResponse, error) {
+	return c.UpdateContext(context.Background(), acc)
+}
+
+// UpdateContext is like Update but carries a context.
+// If the context is canceled or its deadline is exceeded before the response
+// is received, it returns nil, along with a *RequestCanceledError.
+func (c *Client) UpdateContext(ctx context.Context, acc Account) (res *AccountApiResponse, err error) {
+	ctx, end := c.startOperation(ctx, accountOperation(OperationUpdate, acc))
+	defer func() { end(res.statusCode(), err) }()
+
+	if acc.Data == nil || acc.Data.Version == nil {
+		return nil, errors.New(update_missing_id_or_version_formatting)
+	}
+	id, err := uuid.Parse(acc.Data.ID)
+	if err != nil {
+		return nil, errors.New(update_missing_id_or_version_formatting)
+	}
+
+	if c.strictEnums {
+		if err := checkEnums(acc.Data); err != nil {
+			return nil, err
+		}
+	}
+	accountJSON, err := jsonMarshal(acc)
+	if err != nil {
+		return nil, err
+	}
+
+	request, err := newReq(ctx, c, updateMethod, id, nil)
+	if err != nil {
+		return nil, err
+	}
+	setJSONBody(request, accountJSON)
+
+	response, err := apiCall(c, request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+	return handleRes(response, updateMethod)
+}
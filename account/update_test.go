@@ -0,0 +1,138 @@
+package account
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+var (
+	exp_res_updated_success = &AccountApiResponse{
+		ResponseBody: &test_acc,
+		StatusCode:   http.StatusOK,
+		Status:       "OK",
+	}
+)
+
+func TestUpdate(t *testing.T) {
+	restoreStubs(t)
+	version := int64(0)
+	updateAcc := Account{
+		Data: &AccountData{
+			ID:      test_acc.Data.ID,
+			Version: &version,
+			Attributes: &AccountAttributes{
+				Name: []string{"Samantha Holder"},
+			},
+		},
+	}
+	subtests := []struct {
+		name             string
+		acc              Account
+		newReq           func(ctx context.Context, c *Client, verb httpMethod, id uuid.UUID, version *int64) (*http.Request, error)
+		handleRes        func(response *http.Response, verb httpMethod) (*AccountApiResponse, error)
+		apiCall          func(c *Client, req *http.Request) (*http.Response, error)
+		expectedResponse *AccountApiResponse
+		expectedErr      error
+	}{
+		{
+			name: "Successfully updated",
+			acc:  updateAcc,
+			newReq: func(ctx context.Context, c *Client, verb httpMethod, id uuid.UUID, version *int64) (*http.Request, error) {
+				return &http.Request{
+					Header: make(http.Header),
+				}, nil
+			},
+			handleRes: func(response *http.Response, verb httpMethod) (*AccountApiResponse, error) {
+				return exp_res_updated_success, nil
+			},
+			apiCall: func(c *Client, req *http.Request) (*http.Response, error) {
+				return &http.Response{
+					Status:     "OK",
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(bytes.NewBufferString("body")),
+				}, nil
+			},
+			expectedResponse: exp_res_updated_success,
+		},
+		{
+			name: "Version conflict",
+			acc:  updateAcc,
+			newReq: func(ctx context.Context, c *Client, verb httpMethod, id uuid.UUID, version *int64) (*http.Request, error) {
+				return &http.Request{
+					Header: make(http.Header),
+				}, nil
+			},
+			handleRes: func(response *http.Response, verb httpMethod) (*AccountApiResponse, error) {
+				return nil, &VersionConflictError{&ApiError{
+					StatusCode: 409,
+					Status:     "Conflict",
+				}}
+			},
+			apiCall: func(c *Client, req *http.Request) (*http.Response, error) {
+				return &http.Response{
+					Status:     "Conflict",
+					StatusCode: http.StatusConflict,
+					Body:       io.NopCloser(bytes.NewBufferString("")),
+				}, nil
+			},
+			expectedErr: &VersionConflictError{&ApiError{
+				StatusCode: 409,
+				Status:     "Conflict",
+			}},
+		},
+		{
+			name:        "Missing version",
+			acc:         Account{Data: &AccountData{ID: test_acc.Data.ID}},
+			expectedErr: errors.New(update_missing_id_or_version_formatting),
+		},
+		{
+			name:        "Invalid id",
+			acc:         Account{Data: &AccountData{ID: "invalid", Version: &version}},
+			expectedErr: errors.New(update_missing_id_or_version_formatting),
+		},
+		{
+			name: "Api Call returns error",
+			acc:  updateAcc,
+			apiCall: func(c *Client, req *http.Request) (*http.Response, error) {
+				return nil, errors.New("Failed to do api call")
+			},
+			newReq: func(ctx context.Context, c *Client, verb httpMethod, id uuid.UUID, version *int64) (*http.Request, error) {
+				return &http.Request{
+					Header: make(http.Header),
+				}, nil
+			},
+			expectedErr: errors.New("Failed to do api call"),
+		},
+		{
+			name: "New Request With Headers returns error",
+			acc:  updateAcc,
+			newReq: func(ctx context.Context, c *Client, verb httpMethod, id uuid.UUID, version *int64) (*http.Request, error) {
+				return nil, errors.New("Failed to create new request")
+			},
+			expectedErr: errors.New("Failed to create new request"),
+		},
+	}
+	for _, subtest := range subtests {
+		t.Run(subtest.name, func(t *testing.T) {
+			newReq = subtest.newReq
+			handleRes = subtest.handleRes
+			apiCall = subtest.apiCall
+			jsonMarshal = json.Marshal
+			result, err := Update(subtest.acc)
+			if err != nil && subtest.expectedErr.Error() != err.Error() {
+				t.Errorf("expected error (%v), got error (%v)", subtest.expectedErr, err)
+			}
+			if !reflect.DeepEqual(result, subtest.expectedResponse) {
+				t.Errorf("expected (%+v), got (%+v)", subtest.expectedResponse, result)
+			}
+		})
+	}
+}
@@ -1,9 +1,12 @@
 package account
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
 	"strconv"
@@ -12,31 +15,35 @@ import (
 	"github.com/google/uuid"
 )
 
+// The variables below configure the Client used by the package level functions
+// (Create, Fetch, Delete). Callers which need several configurations at once
+// should create their own Client through NewClient instead.
 var (
 	// Host must contain API http scheme + actual hostname including possible port.
 	// It should end with forward slash.
 	// Example: "http://localhost:8080/"
-	Host string = "http://localhost:8080/"
+	Host string = DefaultHost
 	// ApiVersion contains api version.
 	// It will be concatenated after the Host variable, therefore
 	// it shouldn't have a slash prefix, but should have a slash postfix.
 	// Example: "v1/"
-	ApiVersion string = "v1/"
+	ApiVersion string = DefaultApiVersion
 	// ApiClient is a pointer to the standard http client which will execute the http requests.
 	// Has a timeout of 1 second.
-	ApiClient *http.Client = &http.Client{Timeout: time.Duration(1) * time.Second}
+	ApiClient *http.Client = &http.Client{Timeout: DefaultTimeout}
 )
 
 var (
-	apiCall             = ApiClient.Do
+	apiCall             = doWithRetries
 	jsonMarshal         = json.Marshal
 	jsonUnmarshal       = json.Unmarshal
-	httpNewRequest      = http.NewRequest
+	httpNewRequest      = http.NewRequestWithContext
 	readRespBody        = ioutil.ReadAll
 	newReq              = newRequestWithHeaders
 	handleRes           = handleResponse
 	handleCreateOrFetch = handleResponseForCreateOrFetch
 	handleDelete        = handleDeleteResponse
+	handleList          = handleListResponse
 )
 
 type httpMethod int
@@ -45,25 +52,66 @@ const (
 	createMethod httpMethod = iota
 	fetchMethod
 	deleteMethod
+	listMethod
+	updateMethod
 	accountsEndpoint                          = "organisation/accounts"
-	api_error_formatting                      = "ACCOUNT API ERROR\nSTATUS CODE : %d\nSTATUS : %s\nRESPONSE BODY : %s\nMESSAGE : %s"
 	delete_incorrect_status_code_formatting   = "DELETE OPERATION GOT INCORRECT STATUS CODE. EXPECTED: %d, GOT: %d"
 	create_incorrect_status_code_formatting   = "CREATE OPERATION GOT INCORRECT STATUS CODE. EXPECTED: %d, GOT: %d"
 	fetch_incorrect_status_code_formatting    = "FETCH OPERATION GOT INCORRECT STATUS CODE. EXPECTED: %d, GOT: %d"
+	list_incorrect_status_code_formatting     = "LIST OPERATION GOT INCORRECT STATUS CODE. EXPECTED: %d, GOT: %d"
+	update_incorrect_status_code_formatting   = "UPDATE OPERATION GOT INCORRECT STATUS CODE. EXPECTED: %d, GOT: %d"
+	update_missing_id_or_version_formatting   = "UPDATE OPERATION REQUIRES ACCOUNT DATA WITH A VALID ID AND A VERSION"
+	delete_latest_missing_version_formatting  = "DELETE LATEST OPERATION FETCHED AN ACCOUNT WITHOUT VERSION"
 	error_status_code_formatting              = "GOT ERROR STATUS CODE OF %d, STATUS %s"
 	create_or_fetch_incorrect_verb_formatting = "HANDLE CREATE OR FETCH FUNCTION CALLED WITH INCORRECT HTTP VERB"
 )
 
 func (index httpMethod) String() string {
-	return [...]string{"POST", "GET", "DELETE"}[index]
+	return [...]string{"POST", "GET", "DELETE", "GET", "PATCH"}[index]
 }
 
-var newRequestWithHeaders = func(verb httpMethod, id uuid.UUID, version *int64) (*http.Request, error) {
-	req, err := httpNewRequest(verb.String(), endpointString(id), nil)
+var doRequest = func(c *Client, req *http.Request) (*http.Response, error) {
+	response, err := sendAuthenticated(c, req)
+	if err != nil || response.StatusCode != http.StatusUnauthorized {
+		return response, err
+	}
+	// A rejected bearer token may have been revoked or expired early:
+	// retry once with a new token.
+	bearer, ok := c.authenticator.(*BearerTokenAuth)
+	if !ok || !bearer.invalidate(req) {
+		return response, nil
+	}
+	retryReq, err := rewindRequest(req, 2)
+	if err != nil {
+		return response, nil
+	}
+	io.Copy(ioutil.Discard, response.Body)
+	response.Body.Close()
+	return sendAuthenticated(c, retryReq)
+}
+
+func sendAuthenticated(c *Client, req *http.Request) (*http.Response, error) {
+	if c.authenticator != nil {
+		if err := c.authenticator.Authenticate(req); err != nil {
+			return nil, err
+		}
+	}
+	response, err := c.httpClient.Do(req)
+	if err != nil {
+		if ctxErr := req.Context().Err(); ctxErr != nil {
+			return nil, &RequestCanceledError{ctxErr}
+		}
+		return nil, err
+	}
+	return response, nil
+}
+
+var newRequestWithHeaders = func(ctx context.Context, c *Client, verb httpMethod, id uuid.UUID, version *int64) (*http.Request, error) {
+	req, err := httpNewRequest(ctx, verb.String(), endpointString(c, id), nil)
 	if err != nil {
 		return nil, err
 	}
-	req.Header.Add("Host", Host)
+	req.Header.Add("Host", c.host)
 	req.Header.Add("Date", time.Now().Format(time.RFC3339Nano))
 	req.Header.Add("Accept", "application/vnd.api+json")
 
@@ -75,8 +123,19 @@ var newRequestWithHeaders = func(verb httpMethod, id uuid.UUID, version *int64)
 	return req, nil
 }
 
-var endpointString = func(id uuid.UUID) string {
-	finalEndpoint := Host + ApiVersion + accountsEndpoint
+var setJSONBody = func(req *http.Request, body []byte) {
+	req.Header.Add("Content-Type", "application/vnd.api+json")
+	req.Header.Add("Content-Length", strconv.Itoa(len(body)))
+	req.Header.Add("Digest", digestHeader(body))
+	req.ContentLength = int64(len(body))
+	req.Body = ioutil.NopCloser(bytes.NewReader(body))
+	req.GetBody = func() (io.ReadCloser, error) {
+		return ioutil.NopCloser(bytes.NewReader(body)), nil
+	}
+}
+
+var endpointString = func(c *Client, id uuid.UUID) string {
+	finalEndpoint := c.host + c.apiVersion + accountsEndpoint
 	if id == uuid.Nil {
 		return finalEndpoint
 	} else {
@@ -95,7 +154,7 @@ var handleResponse = func(response *http.Response, verb httpMethod) (*AccountApi
 	}
 	if response.StatusCode < http.StatusBadRequest {
 		switch verb {
-		case createMethod, fetchMethod:
+		case createMethod, fetchMethod, updateMethod:
 			return handleCreateOrFetch(responseBody, responseWrapper, verb)
 		case deleteMethod:
 			return handleDelete(responseWrapper, responseBody)
@@ -103,8 +162,12 @@ var handleResponse = func(response *http.Response, verb httpMethod) (*AccountApi
 			return nil, errors.New("UNHANDLED HTTP VERB")
 		}
 	} else {
-		return nil, &ApiError{responseWrapper.StatusCode, responseWrapper.Status, string(responseBody),
-			fmt.Sprintf(error_status_code_formatting, responseWrapper.StatusCode, responseWrapper.Status)}
+		apiErr := newApiError(responseWrapper.StatusCode, responseWrapper.Status, responseBody,
+			fmt.Sprintf(error_status_code_formatting, responseWrapper.StatusCode, responseWrapper.Status))
+		if responseWrapper.StatusCode == http.StatusConflict && (verb == updateMethod || verb == deleteMethod) {
+			return nil, &VersionConflictError{apiErr}
+		}
+		return nil, apiErr
 	}
 }
 
@@ -112,13 +175,18 @@ var handleResponseForCreateOrFetch = func(responseBody []byte, responseWrapper A
 	switch verb {
 	case createMethod:
 		if responseWrapper.StatusCode != http.StatusCreated {
-			return nil, &ApiError{responseWrapper.StatusCode, responseWrapper.Status, string(responseBody),
-				fmt.Sprintf(create_incorrect_status_code_formatting, http.StatusCreated, responseWrapper.StatusCode)}
+			return nil, newApiError(responseWrapper.StatusCode, responseWrapper.Status, responseBody,
+				fmt.Sprintf(create_incorrect_status_code_formatting, http.StatusCreated, responseWrapper.StatusCode))
 		}
 	case fetchMethod:
 		if responseWrapper.StatusCode != http.StatusOK {
-			return nil, &ApiError{responseWrapper.StatusCode, responseWrapper.Status, string(responseBody),
-				fmt.Sprintf(fetch_incorrect_status_code_formatting, http.StatusOK, responseWrapper.StatusCode)}
+			return nil, newApiError(responseWrapper.StatusCode, responseWrapper.Status, responseBody,
+				fmt.Sprintf(fetch_incorrect_status_code_formatting, http.StatusOK, responseWrapper.StatusCode))
+		}
+	case updateMethod:
+		if responseWrapper.StatusCode != http.StatusOK {
+			return nil, newApiError(responseWrapper.StatusCode, responseWrapper.Status, responseBody,
+				fmt.Sprintf(update_incorrect_status_code_formatting, http.StatusOK, responseWrapper.StatusCode))
 		}
 	default:
 		return nil, errors.New(create_or_fetch_incorrect_verb_formatting)
@@ -136,24 +204,7 @@ var handleDeleteResponse = func(responseWrapper AccountApiResponse, responseBody
 	if responseWrapper.StatusCode == http.StatusNoContent {
 		return &responseWrapper, nil
 	} else {
-		return nil, &ApiError{responseWrapper.StatusCode, responseWrapper.Status, string(responseBody),
-			fmt.Sprintf(delete_incorrect_status_code_formatting, http.StatusNoContent, responseWrapper.StatusCode)}
+		return nil, newApiError(responseWrapper.StatusCode, responseWrapper.Status, responseBody,
+			fmt.Sprintf(delete_incorrect_status_code_formatting, http.StatusNoContent, responseWrapper.StatusCode))
 	}
 }
-
-// ApiError is a custom error being returned in case of an error response from form3 API.
-type ApiError struct {
-	StatusCode   int
-	Status       string
-	ResponseBody string
-	Message      string
-}
-
-func (e *ApiError) Error() string {
-	return fmt.Sprintf(api_error_formatting,
-		e.StatusCode, e.Status, e.ResponseBody, e.Message)
-}
-
-func (e *ApiError) Is(tgt error) bool {
-	return e.Error() == tgt.Error()
-}
@@ -2,9 +2,11 @@ package account
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"io"
 	"net/http"
+	"net/http/httptest"
 	"net/url"
 	"reflect"
 	"testing"
@@ -34,6 +36,16 @@ func TestHttpVerbString(t *testing.T) {
 			deleteMethod,
 			"DELETE",
 		},
+		{
+			"Verb LIST mapped successfully",
+			listMethod,
+			"GET",
+		},
+		{
+			"Verb UPDATE mapped successfully",
+			updateMethod,
+			"PATCH",
+		},
 	}
 	for _, subtest := range subtests {
 		t.Run(subtest.name, func(t *testing.T) {
@@ -74,7 +86,7 @@ func TestEndpointString(t *testing.T) {
 	}
 	for _, subtest := range subtests {
 		t.Run(subtest.name, func(t *testing.T) {
-			result := endpointString(subtest.id)
+			result := endpointString(NewClient(WithHost(subtest.Host), WithApiVersion(subtest.ApiVersion)), subtest.id)
 			if result != subtest.expResp {
 				t.Errorf("expected endpoint string (%s), got (%s)", subtest.expResp, result)
 			}
@@ -83,10 +95,11 @@ func TestEndpointString(t *testing.T) {
 }
 
 func TestNewRequestWithHeaders(t *testing.T) {
+	restoreStubs(t)
 
 	subtests := []struct {
 		name            string
-		httpNewRequest  func(method, url string, body io.Reader) (*http.Request, error)
+		httpNewRequest  func(ctx context.Context, method, url string, body io.Reader) (*http.Request, error)
 		httpVerb        httpMethod
 		id              uuid.UUID
 		version         *int64
@@ -97,7 +110,7 @@ func TestNewRequestWithHeaders(t *testing.T) {
 	}{
 		{
 			name: "New POST request with Headers",
-			httpNewRequest: func(method, httpUrl string, body io.Reader) (*http.Request, error) {
+			httpNewRequest: func(ctx context.Context, method, httpUrl string, body io.Reader) (*http.Request, error) {
 				return &http.Request{
 					Header: make(http.Header),
 					Method: http.MethodPost,
@@ -113,7 +126,7 @@ func TestNewRequestWithHeaders(t *testing.T) {
 		},
 		{
 			name: "New DELETE request with Headers",
-			httpNewRequest: func(method, httpUrl string, body io.Reader) (*http.Request, error) {
+			httpNewRequest: func(ctx context.Context, method, httpUrl string, body io.Reader) (*http.Request, error) {
 				return &http.Request{
 					Header:     make(http.Header),
 					RequestURI: "http://localhost:8080/v1/organization/accounts",
@@ -131,7 +144,7 @@ func TestNewRequestWithHeaders(t *testing.T) {
 		},
 		{
 			name: "http.NewRequest returns error",
-			httpNewRequest: func(method, url string, body io.Reader) (*http.Request, error) {
+			httpNewRequest: func(ctx context.Context, method, url string, body io.Reader) (*http.Request, error) {
 				return nil, errors.New("Error")
 			},
 			expError: errors.New("Error"),
@@ -141,7 +154,7 @@ func TestNewRequestWithHeaders(t *testing.T) {
 	for _, subtest := range subtests {
 		t.Run(subtest.name, func(t *testing.T) {
 			httpNewRequest = subtest.httpNewRequest
-			result, err := newRequestWithHeaders(subtest.httpVerb, subtest.id, subtest.version)
+			result, err := newRequestWithHeaders(context.Background(), defaultClient(), subtest.httpVerb, subtest.id, subtest.version)
 			if err != nil {
 				if subtest.expError.Error() != err.Error() {
 					t.Errorf("expected error (%+v), got (%+v)", subtest.expError, err)
@@ -180,6 +193,7 @@ func int64ToPointer(i int64) *int64 {
 }
 
 func TestHandleResponse(t *testing.T) {
+	restoreStubs(t)
 	subtests := []struct {
 		name                string
 		httpVerb            httpMethod
@@ -257,6 +271,25 @@ func TestHandleResponse(t *testing.T) {
 				Message:      "GOT ERROR STATUS CODE OF 400, STATUS Bad request",
 			},
 		},
+		{
+			name:     "Handle Status CONFLICT 409 on UPDATE",
+			httpVerb: updateMethod,
+			responseParam: &http.Response{
+				Status:     "Conflict",
+				StatusCode: http.StatusConflict,
+				Body:       io.NopCloser(bytes.NewBufferString("")),
+			},
+			expResponse: nil,
+			readRespBody: func(r io.Reader) ([]byte, error) {
+				return []byte("invalid version"), nil
+			},
+			expError: &VersionConflictError{&ApiError{
+				StatusCode:   http.StatusConflict,
+				Status:       "Conflict",
+				ResponseBody: "invalid version",
+				Message:      "GOT ERROR STATUS CODE OF 409, STATUS Conflict",
+			}},
+		},
 		{
 			name: "ioutil.ReadAll (readRespBody) returns error",
 			readRespBody: func(r io.Reader) ([]byte, error) {
@@ -289,6 +322,7 @@ func TestHandleResponse(t *testing.T) {
 }
 
 func TestHandleResponseForCreateOrFetch(t *testing.T) {
+	restoreStubs(t)
 	subtests := []struct {
 		name            string
 		httpVerb        httpMethod
@@ -361,6 +395,38 @@ func TestHandleResponseForCreateOrFetch(t *testing.T) {
 			},
 			expError: errors.New("Error during unmarshaling"),
 		},
+		{
+			name:         "Handle UPDATE response with correct status code",
+			httpVerb:     updateMethod,
+			responseBody: []byte("Resp Body"),
+			responseWrapper: AccountApiResponse{
+				StatusCode: http.StatusOK,
+				Status:     "OK",
+			},
+			verb: updateMethod,
+			jsonUnmarshal: func(data []byte, v any) error {
+				a := v.(*Account)
+				*a = *exp_res_fetch_success.ResponseBody
+				return nil
+			},
+			expResponse: exp_res_fetch_success,
+		},
+		{
+			name:         "Handle UPDATE response with incorrect status code",
+			httpVerb:     updateMethod,
+			responseBody: []byte("Resp Body"),
+			responseWrapper: AccountApiResponse{
+				StatusCode: http.StatusCreated,
+				Status:     "Created",
+			},
+			verb: updateMethod,
+			expError: &ApiError{
+				StatusCode:   http.StatusCreated,
+				Status:       "Created",
+				ResponseBody: "Resp Body",
+				Message:      "UPDATE OPERATION GOT INCORRECT STATUS CODE. EXPECTED: 200, GOT: 201",
+			},
+		},
 		{
 			name:         "Handle FETCH response with incorrect status code",
 			httpVerb:     fetchMethod,
@@ -460,3 +526,96 @@ func TestHandleDeleteResponse(t *testing.T) {
 		})
 	}
 }
+
+func TestDoRequest(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(time.Duration(200) * time.Millisecond):
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	canceledCtx, cancel := context.WithCancel(context.Background())
+	cancel()
+	expiredCtx, cancelExpired := context.WithTimeout(context.Background(), time.Duration(10)*time.Millisecond)
+	defer cancelExpired()
+
+	subtests := []struct {
+		name          string
+		ctx           context.Context
+		expStatusCode int
+		expCtxErr     error
+	}{
+		{
+			name:          "Request completes",
+			ctx:           context.Background(),
+			expStatusCode: http.StatusOK,
+		},
+		{
+			name:      "Context canceled",
+			ctx:       canceledCtx,
+			expCtxErr: context.Canceled,
+		},
+		{
+			name:      "Context deadline exceeded",
+			ctx:       expiredCtx,
+			expCtxErr: context.DeadlineExceeded,
+		},
+	}
+	for _, subtest := range subtests {
+		t.Run(subtest.name, func(t *testing.T) {
+			req, err := http.NewRequestWithContext(subtest.ctx, http.MethodGet, server.URL, nil)
+			if err != nil {
+				t.Fatalf("unexpected error (%v)", err)
+			}
+			result, err := doRequest(NewClient(), req)
+			if subtest.expCtxErr != nil {
+				var canceledErr *RequestCanceledError
+				if !errors.As(err, &canceledErr) {
+					t.Fatalf("expected error of type RequestCanceledError, got (%v)", err)
+				}
+				if !errors.Is(err, subtest.expCtxErr) {
+					t.Errorf("expected error wrapping (%v), got (%v)", subtest.expCtxErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expected nil error, got (%v)", err)
+			}
+			defer result.Body.Close()
+			if result.StatusCode != subtest.expStatusCode {
+				t.Errorf("expected status code (%d), got (%d)", subtest.expStatusCode, result.StatusCode)
+			}
+		})
+	}
+}
+
+// restoreStubs saves the package level function variables stubbed by the tests
+// and restores them once t ends, so that stubs never leak into the following tests.
+func restoreStubs(t *testing.T) {
+	saved := struct {
+		apiCall             func(c *Client, req *http.Request) (*http.Response, error)
+		doRequest           func(c *Client, req *http.Request) (*http.Response, error)
+		jsonMarshal         func(v any) ([]byte, error)
+		jsonUnmarshal       func(data []byte, v any) error
+		httpNewRequest      func(ctx context.Context, method, url string, body io.Reader) (*http.Request, error)
+		readRespBody        func(r io.Reader) ([]byte, error)
+		newReq              func(ctx context.Context, c *Client, verb httpMethod, id uuid.UUID, version *int64) (*http.Request, error)
+		handleRes           func(response *http.Response, verb httpMethod) (*AccountApiResponse, error)
+		handleCreateOrFetch func(responseBody []byte, responseWrapper AccountApiResponse, verb httpMethod) (*AccountApiResponse, error)
+		handleDelete        func(responseWrapper AccountApiResponse, responseBody []byte) (*AccountApiResponse, error)
+		handleList          func(response *http.Response) (*AccountListApiResponse, error)
+		sleep               func(ctx context.Context, d time.Duration) error
+		timeNow             func() time.Time
+		randFloat           func() float64
+	}{apiCall, doRequest, jsonMarshal, jsonUnmarshal, httpNewRequest, readRespBody, newReq, handleRes,
+		handleCreateOrFetch, handleDelete, handleList, sleep, timeNow, randFloat}
+	t.Cleanup(func() {
+		apiCall, doRequest, jsonMarshal, jsonUnmarshal = saved.apiCall, saved.doRequest, saved.jsonMarshal, saved.jsonUnmarshal
+		httpNewRequest, readRespBody, newReq, handleRes = saved.httpNewRequest, saved.readRespBody, saved.newReq, saved.handleRes
+		handleCreateOrFetch, handleDelete, handleList = saved.handleCreateOrFetch, saved.handleDelete, saved.handleList
+		sleep, timeNow, randFloat = saved.sleep, saved.timeNow, saved.randFloat
+	})
+}
@@ -0,0 +1,166 @@
+package account
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/edihoxhalli/Form3-exercise/account/iban"
+	"github.com/google/uuid"
+)
+
+const (
+	accountsType          = "accounts"
+	maxNameLength         = 140
+	maxNames              = 4
+	maxAlternativeNames   = 3
+	validation_formatting = "ACCOUNT VALIDATION FAILED: %s"
+)
+
+var (
+	bicPattern = regexp.MustCompile(`^[A-Z]{6}[A-Z0-9]{2}([A-Z0-9]{3})?$`)
+)
+
+// FieldViolation describes a single invalid field of an Account.
+// Field is the JSON path of the field, e.g. "data.attributes.country".
+type FieldViolation struct {
+	Field   string
+	Message string
+}
+
+func (v FieldViolation) String() string {
+	return v.Field + " " + v.Message
+}
+
+// ValidationError is returned by Validate, and by Create when validation is enabled
+// through WithValidation, listing every invalid field of the Account.
+// It matches ErrValidation through errors.Is.
+type ValidationError struct {
+	Violations []FieldViolation
+}
+
+func (e *ValidationError) Error() string {
+	violations := make([]string, 0, len(e.Violations))
+	for _, v := range e.Violations {
+		violations = append(violations, v.String())
+	}
+	return fmt.Sprintf(validation_formatting, strings.Join(violations, "; "))
+}
+
+func (e *ValidationError) Is(tgt error) bool {
+	return tgt == ErrValidation
+}
+
+// WithValidation makes Create validate the Account through Account.Validate
+// before sending it, returning a *ValidationError without calling the form3 API
+// when the Account is invalid. Combined with WithStrictEnums, unknown enumeration
+// values are reported as violations as well.
+func WithValidation() Option {
+	return func(c *Client) {
+		c.validateOnCreate = true
+	}
+}
+
+// Validate checks the Account client side, before it is created on the form3 API.
+// It returns nil if the Account is valid, or a *ValidationError listing every violation.
+func (acc Account) Validate() error {
+	return acc.validate(false)
+}
+
+func (acc Account) validate(strictEnums bool) error {
+	if acc.Data == nil {
+		return &ValidationError{[]FieldViolation{{"data", "is required"}}}
+	}
+	return acc.Data.validate(strictEnums)
+}
+
+// Validate checks the AccountData client side, before it is created on the form3 API.
+// Besides the generic checks, it applies the CountryRule registered for the country of the Account.
+// Unknown Classification, Status and BankIDCode values are accepted, unless validating
+// through a Client created with WithStrictEnums.
+// It returns nil if the AccountData is valid, or a *ValidationError listing every violation.
+func (data AccountData) Validate() error {
+	return data.validate(false)
+}
+
+func (data AccountData) validate(strictEnums bool) error {
+	var v validator
+	if _, err := uuid.Parse(data.ID); err != nil {
+		v.add("data.id", "must be a valid UUID")
+	}
+	if _, err := uuid.Parse(data.OrganisationID); err != nil {
+		v.add("data.organisation_id", "must be a valid UUID")
+	}
+	if data.Type != accountsType {
+		v.add("data.type", fmt.Sprintf("must be %q", accountsType))
+	}
+	if data.Attributes == nil {
+		v.add("data.attributes", "is required")
+	} else {
+		data.Attributes.validate(&v, strictEnums)
+	}
+	return v.err()
+}
+
+func (attrs AccountAttributes) validate(v *validator, strictEnums bool) {
+	if attrs.Country == nil || !isCountryCode(*attrs.Country) {
+		v.add("data.attributes.country", "must be an ISO 3166-1 alpha-2 country code")
+	} else if rule, ok := LookupCountryRule(*attrs.Country); ok {
+		v.violations = append(v.violations, rule.Validate(attrs)...)
+	}
+	if strictEnums {
+		attrs.validateEnums(v)
+	}
+	if attrs.BaseCurrency != "" && !isCurrencyCode(attrs.BaseCurrency) {
+		v.add("data.attributes.base_currency", "must be an ISO 4217 currency code")
+	}
+	if attrs.Bic != "" && !bicPattern.MatchString(attrs.Bic) {
+		v.add("data.attributes.bic", "must be a valid SWIFT BIC")
+	}
+	if attrs.Iban != "" && iban.Validate(attrs.Iban) != nil {
+		v.add("data.attributes.iban", "must be a valid IBAN")
+	}
+	v.names("data.attributes.name", attrs.Name, maxNames)
+	v.names("data.attributes.alternative_names", attrs.AlternativeNames, maxAlternativeNames)
+	if len(attrs.SecondaryIdentification) > maxNameLength {
+		v.add("data.attributes.secondary_identification", fmt.Sprintf("must be at most %d characters", maxNameLength))
+	}
+}
+
+func (attrs AccountAttributes) validateEnums(v *validator) {
+	if attrs.AccountClassification != nil && !attrs.AccountClassification.Valid() {
+		v.add("data.attributes.account_classification", "must be one of "+strings.Join(enumStrings(classifications), ", "))
+	}
+	if attrs.BankIDCode != "" && !attrs.BankIDCode.Valid() {
+		v.add("data.attributes.bank_id_code", "must be one of "+strings.Join(enumStrings(bankIDCodes), ", "))
+	}
+	if attrs.Status != nil && !attrs.Status.Valid() {
+		v.add("data.attributes.status", "must be one of "+strings.Join(enumStrings(statuses), ", "))
+	}
+}
+
+type validator struct {
+	violations []FieldViolation
+}
+
+func (v *validator) add(field, message string) {
+	v.violations = append(v.violations, FieldViolation{field, message})
+}
+
+func (v *validator) names(field string, names []string, max int) {
+	if len(names) > max {
+		v.add(field, fmt.Sprintf("must have at most %d entries", max))
+	}
+	for i, name := range names {
+		if name == "" || len(name) > maxNameLength {
+			v.add(fmt.Sprintf("%s[%d]", field, i), fmt.Sprintf("must be between 1 and %d characters", maxNameLength))
+		}
+	}
+}
+
+func (v *validator) err() error {
+	if len(v.violations) == 0 {
+		return nil
+	}
+	return &ValidationError{v.violations}
+}
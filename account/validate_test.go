@@ -0,0 +1,158 @@
+package account
+
+import (
+	"errors"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func validAccount() Account {
+	return Account{
+		Data: &AccountData{
+			Attributes: &AccountAttributes{
+				Country:      newStringPointer("GB"),
+				BaseCurrency: "GBP",
+				BankID:       "601613",
+				BankIDCode:   "GBDSC",
+				Bic:          "NWBKGB22",
+				Iban:         "GB29NWBK60161331926819",
+				Name:         []string{"Samantha Holder"},
+			},
+			Type:           "accounts",
+			ID:             "ad27e265-9605-4b4b-a0e5-3003ea9cc4dc",
+			OrganisationID: "eb0bd6f5-c3f5-44b2-b677-acd23cdde73c",
+		},
+	}
+}
+
+func TestValidate(t *testing.T) {
+	subtests := []struct {
+		name          string
+		modify        func(acc *Account)
+		expViolations []FieldViolation
+	}{
+		{
+			name:   "Valid account",
+			modify: func(acc *Account) {},
+		},
+		{
+			name:          "Missing data",
+			modify:        func(acc *Account) { acc.Data = nil },
+			expViolations: []FieldViolation{{"data", "is required"}},
+		},
+		{
+			name: "Invalid data fields",
+			modify: func(acc *Account) {
+				acc.Data.ID = "123"
+				acc.Data.OrganisationID = ""
+				acc.Data.Type = "account"
+				acc.Data.Attributes = nil
+			},
+			expViolations: []FieldViolation{
+				{"data.id", "must be a valid UUID"},
+				{"data.organisation_id", "must be a valid UUID"},
+				{"data.type", `must be "accounts"`},
+				{"data.attributes", "is required"},
+			},
+		},
+		{
+			name: "Invalid attributes",
+			modify: func(acc *Account) {
+				attrs := acc.Data.Attributes
+				attrs.Country = newStringPointer("UK")
+				attrs.BaseCurrency = "GBX"
+				attrs.Bic = "NWBK22"
+				attrs.Iban = "GB11NWBK60161331926819"
+				attrs.Name = []string{"a", "b", "c", "d", ""}
+				attrs.AlternativeNames = []string{strings.Repeat("a", 141)}
+				attrs.SecondaryIdentification = strings.Repeat("a", 141)
+			},
+			expViolations: []FieldViolation{
+				{"data.attributes.country", "must be an ISO 3166-1 alpha-2 country code"},
+				{"data.attributes.base_currency", "must be an ISO 4217 currency code"},
+				{"data.attributes.bic", "must be a valid SWIFT BIC"},
+				{"data.attributes.iban", "must be a valid IBAN"},
+				{"data.attributes.name", "must have at most 4 entries"},
+				{"data.attributes.name[4]", "must be between 1 and 140 characters"},
+				{"data.attributes.alternative_names[0]", "must be between 1 and 140 characters"},
+				{"data.attributes.secondary_identification", "must be at most 140 characters"},
+			},
+		},
+		{
+			name:          "Missing country",
+			modify:        func(acc *Account) { acc.Data.Attributes.Country = nil },
+			expViolations: []FieldViolation{{"data.attributes.country", "must be an ISO 3166-1 alpha-2 country code"}},
+		},
+	}
+	for _, subtest := range subtests {
+		t.Run(subtest.name, func(t *testing.T) {
+			acc := validAccount()
+			subtest.modify(&acc)
+			err := acc.Validate()
+			if subtest.expViolations == nil {
+				if err != nil {
+					t.Errorf("expected nil error, got (%v)", err)
+				}
+				return
+			}
+			var validationErr *ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("expected ValidationError, got (%v)", err)
+			}
+			if !reflect.DeepEqual(validationErr.Violations, subtest.expViolations) {
+				t.Errorf("expected violations (%+v), got (%+v)", subtest.expViolations, validationErr.Violations)
+			}
+			if !errors.Is(err, ErrValidation) {
+				t.Errorf("expected error to match ErrValidation")
+			}
+		})
+	}
+}
+
+func TestValidIbanChecksum(t *testing.T) {
+	subtests := []struct {
+		iban  string
+		valid bool
+	}{
+		{"GB29NWBK60161331926819", true},
+		{"DE89370400440532013000", true},
+		{"FR1420041010050500013M02606", true},
+		{"SE4550000000058398257466", true},
+		{"NO9386011117947", true},
+		{"DK5000400440116243", true},
+		{"GB28NWBK60161331926819", false},
+		{"NO9486011117947", false},
+		{"gb29nwbk60161331926819", false},
+		{"GB29", false},
+	}
+	for _, subtest := range subtests {
+		attrs := validAccount().Data.Attributes
+		attrs.Country = newStringPointer("SE")
+		attrs.Iban = subtest.iban
+		var v validator
+		attrs.validate(&v, false)
+		if valid := len(v.violations) == 0; valid != subtest.valid {
+			t.Errorf("expected IBAN (%s) validity to be %v, got violations (%v)", subtest.iban, subtest.valid, v.violations)
+		}
+	}
+}
+
+func TestCreateWithValidation(t *testing.T) {
+	restoreStubs(t)
+	apiCall = func(c *Client, req *http.Request) (*http.Response, error) {
+		t.Fatalf("expected no api call for an invalid account")
+		return nil, nil
+	}
+	invalid := validAccount()
+	invalid.Data.Type = ""
+	result, err := NewClient(WithValidation()).Create(invalid)
+	if result != nil {
+		t.Errorf("expected nil result, got (%+v)", result)
+	}
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Errorf("expected ValidationError, got (%v)", err)
+	}
+}
@@ -1,14 +1,18 @@
 // Package it holds Integration (e2e) Tests for package account.
+// They run against the accountapi given by the ACCOUNT_API_HOST environment variable
+// (e.g. "http://accountapi:8080/"), or against the in-process accounttest fake when it is not set.
 package it
 
 import (
 	"log"
 	"net/http"
+	"os"
 	"reflect"
 	"testing"
 
 	"github.com/davecgh/go-spew/spew"
 	"github.com/edihoxhalli/Form3-exercise/account"
+	"github.com/edihoxhalli/Form3-exercise/account/accounttest"
 	"github.com/google/uuid"
 )
 
@@ -17,24 +21,23 @@ var (
 	test_acc = account.Account{
 		Data: &account.AccountData{
 			Attributes: &account.AccountAttributes{
-				AccountClassification: newStringPointer("Personal"),
+				AccountClassification: newClassificationPointer(account.ClassificationPersonal),
 				AccountMatchingOptOut: newBoolPointer(false),
 				AccountNumber:         "1231555",
 				AlternativeNames: []string{
 					"Sam Holder",
 				},
 				BankID:       "400300",
-				BankIDCode:   "GBDSC",
+				BankIDCode:   account.BankIDCodeGBDSC,
 				BaseCurrency: "GBP",
 				Bic:          "NWBKGB22",
 				Country:      newStringPointer("GB"),
-				Iban:         "GB11NWBK40030041426819",
 				JointAccount: newBoolPointer(false),
 				Name: []string{
 					"Samantha Holder",
 				},
 				SecondaryIdentification: "A1B2C3D4",
-				Status:                  newStringPointer("pending"),
+				Status:                  newStatusPointer(account.StatusPending),
 				Switched:                newBoolPointer(true),
 			},
 			Type:           "accounts",
@@ -67,12 +70,34 @@ func newStringPointer(s string) *string {
 	return &s
 }
 
+func newClassificationPointer(c account.Classification) *account.Classification {
+	return &c
+}
+
+func newStatusPointer(s account.Status) *account.Status {
+	return &s
+}
+
 func newInt64Pointer(i int64) *int64 {
 	return &i
 }
 
+func TestMain(m *testing.M) {
+	if host := os.Getenv("ACCOUNT_API_HOST"); host != "" {
+		account.Host = host
+		os.Exit(m.Run())
+	}
+	server := accounttest.NewServer()
+	account.Host = server.Host()
+	code := m.Run()
+	server.Close()
+	os.Exit(code)
+}
+
 func init() {
-	account.Host = "http://accountapi:8080/"
+	if err := test_acc.Data.Attributes.FillIban(); err != nil {
+		l.Fatalf("unable to fill test account IBAN (%v)", err)
+	}
 }
 
 func TestCreateE2E(t *testing.T) {